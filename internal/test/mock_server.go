@@ -1,15 +1,19 @@
 package test
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
@@ -28,8 +32,13 @@ func NewMockServer() *MockServer {
 	scheme := runtime.NewScheme()
 	codecs := serializer.NewCodecFactory(scheme)
 	ms.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Handlers are invoked in order until one of them responds to the request
+		rw := &responseWriter{ResponseWriter: w}
 		for _, handler := range ms.restHandlers {
-			handler(w, req)
+			handler(rw, req)
+			if rw.responded {
+				return
+			}
 		}
 	}))
 	ms.config = &rest.Config{
@@ -45,6 +54,38 @@ func NewMockServer() *MockServer {
 	return ms
 }
 
+// responseWriter keeps track of whether a handler responded to the request
+type responseWriter struct {
+	http.ResponseWriter
+	responded bool
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.responded = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.responded = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	w.responded = true
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.responded = true
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
 func (m *MockServer) Close() {
 	m.server.Close()
 }
@@ -79,6 +120,52 @@ func WriteObject(w http.ResponseWriter, obj runtime.Object) {
 	}
 }
 
+// NewDiscoveryClientHandler returns a handler that serves the requests performed by the DiscoveryClient (API versions,
+// API groups and API resources) for the provided API resource lists, any other request is ignored
+func NewDiscoveryClientHandler(resourceLists ...*metav1.APIResourceList) http.Handler {
+	apiVersions := &metav1.APIVersions{
+		TypeMeta:                   metav1.TypeMeta{Kind: "APIVersions"},
+		Versions:                   []string{},
+		ServerAddressByClientCIDRs: []metav1.ServerAddressByClientCIDR{{ClientCIDR: "0.0.0.0/0"}},
+	}
+	apiGroups := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}, Groups: []metav1.APIGroup{}}
+	apiResources := make(map[string]*metav1.APIResourceList)
+	for _, resourceList := range resourceLists {
+		resourceList.TypeMeta = metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"}
+		gv, _ := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if gv.Group == "" {
+			apiVersions.Versions = append(apiVersions.Versions, gv.Version)
+			apiResources["/api/"+gv.Version] = resourceList
+			continue
+		}
+		apiResources["/apis/"+gv.String()] = resourceList
+		version := metav1.GroupVersionForDiscovery{GroupVersion: gv.String(), Version: gv.Version}
+		found := false
+		for i := range apiGroups.Groups {
+			if apiGroups.Groups[i].Name == gv.Group {
+				apiGroups.Groups[i].Versions = append(apiGroups.Groups[i].Versions, version)
+				found = true
+			}
+		}
+		if !found {
+			apiGroups.Groups = append(apiGroups.Groups, metav1.APIGroup{Name: gv.Group, Versions: []metav1.GroupVersionForDiscovery{version}, PreferredVersion: version})
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		// Request Performed by DiscoveryClient to Kube API (Get API Groups legacy -core-)
+		case req.URL.Path == "/api":
+			WriteObject(w, apiVersions)
+		// Request Performed by DiscoveryClient to Kube API (Get API Groups)
+		case req.URL.Path == "/apis":
+			WriteObject(w, apiGroups)
+		// Request Performed by DiscoveryClient to Kube API (Get API Resources)
+		case apiResources[req.URL.Path] != nil:
+			WriteObject(w, apiResources[req.URL.Path])
+		}
+	})
+}
+
 type streamAndReply struct {
 	httpstream.Stream
 	replySent <-chan struct{}
@@ -92,6 +179,11 @@ type StreamContext struct {
 	writeStatus  func(status *apierrors.StatusError) error
 }
 
+// WriteStatus writes the provided status to the error stream (e.g. to report a non-zero exit code)
+func (s *StreamContext) WriteStatus(status *apierrors.StatusError) error {
+	return s.writeStatus(status)
+}
+
 type StreamOptions struct {
 	Stdin  io.Reader
 	Stdout io.Writer
@@ -22,6 +22,11 @@ type StaticConfig struct {
 	DisableDestructive bool     `toml:"disable_destructive,omitempty"`
 	EnabledTools       []string `toml:"enabled_tools,omitempty"`
 	DisabledTools      []string `toml:"disabled_tools,omitempty"`
+	// KustomizeRoot is the directory of the server host containing the kustomizations that can be built and applied,
+	// the kustomize tools are only exposed when it's set
+	KustomizeRoot string `toml:"kustomize_root,omitempty"`
+	// When true, kustomizations can reference remote bases and resources (git or http URLs) that are fetched by the server
+	KustomizeAllowRemote bool `toml:"kustomize_allow_remote,omitempty"`
 
 	// Authorization-related fields
 	// RequireOAuth indicates whether the server requires OAuth for authentication.
@@ -57,6 +57,8 @@ kubeconfig = "./path/to/config"
 list_output = "yaml"
 read_only = true
 disable_destructive = true
+kustomize_root = "/srv/kustomizations"
+kustomize_allow_remote = true
 
 denied_resources = [
     {group = "apps", version = "v1", kind = "Deployment"},
@@ -121,6 +123,16 @@ disabled_tools = ["pods_delete", "pods_top", "pods_log", "pods_run", "pods_exec"
 			t.Fatalf("Unexpected disable destructive: %v", config.DisableDestructive)
 		}
 	})
+	t.Run("kustomize_root parsed correctly", func(t *testing.T) {
+		if config.KustomizeRoot != "/srv/kustomizations" {
+			t.Fatalf("Unexpected kustomize_root value: %v", config.KustomizeRoot)
+		}
+	})
+	t.Run("kustomize_allow_remote parsed correctly", func(t *testing.T) {
+		if !config.KustomizeAllowRemote {
+			t.Fatalf("Unexpected kustomize allow remote: %v", config.KustomizeAllowRemote)
+		}
+	})
 	t.Run("enabled_tools parsed correctly", func(t *testing.T) {
 		if len(config.EnabledTools) != 8 {
 			t.Fatalf("Unexpected enabled tools: %v", config.EnabledTools)
@@ -61,6 +61,8 @@ type MCPServerOptions struct {
 	ListOutput           string
 	ReadOnly             bool
 	DisableDestructive   bool
+	KustomizeRoot        string
+	KustomizeAllowRemote bool
 	RequireOAuth         bool
 	OAuthAudience        string
 	ValidateToken        bool
@@ -119,6 +121,8 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 	cmd.Flags().StringVar(&o.ListOutput, "list-output", o.ListOutput, "Output format for resource list operations (one of: "+strings.Join(output.Names, ", ")+"). Defaults to table.")
 	cmd.Flags().BoolVar(&o.ReadOnly, "read-only", o.ReadOnly, "If true, only tools annotated with readOnlyHint=true are exposed")
 	cmd.Flags().BoolVar(&o.DisableDestructive, "disable-destructive", o.DisableDestructive, "If true, tools annotated with destructiveHint=true are disabled")
+	cmd.Flags().StringVar(&o.KustomizeRoot, "kustomize-root", o.KustomizeRoot, "Directory of the server host containing the kustomizations that can be built and applied. The kustomize tools are disabled if not set")
+	cmd.Flags().BoolVar(&o.KustomizeAllowRemote, "kustomize-allow-remote", o.KustomizeAllowRemote, "If true, kustomizations can reference remote bases and resources (git or http URLs) that are fetched by the server")
 	cmd.Flags().BoolVar(&o.RequireOAuth, "require-oauth", o.RequireOAuth, "If true, requires OAuth authorization as defined in the Model Context Protocol (MCP) specification. This flag is ignored if transport type is stdio")
 	_ = cmd.Flags().MarkHidden("require-oauth")
 	cmd.Flags().StringVar(&o.OAuthAudience, "oauth-audience", o.OAuthAudience, "OAuth audience for token claims validation. Optional. If not set, the audience is not validated. Only valid if require-oauth is enabled.")
@@ -182,6 +186,12 @@ func (m *MCPServerOptions) loadFlags(cmd *cobra.Command) {
 	if cmd.Flag("disable-destructive").Changed {
 		m.StaticConfig.DisableDestructive = m.DisableDestructive
 	}
+	if cmd.Flag("kustomize-root").Changed {
+		m.StaticConfig.KustomizeRoot = m.KustomizeRoot
+	}
+	if cmd.Flag("kustomize-allow-remote").Changed {
+		m.StaticConfig.KustomizeAllowRemote = m.KustomizeAllowRemote
+	}
 	if cmd.Flag("require-oauth").Changed {
 		m.StaticConfig.RequireOAuth = m.RequireOAuth
 	}
@@ -251,6 +261,8 @@ func (m *MCPServerOptions) Run() error {
 	klog.V(1).Infof(" - ListOutput: %s", listOutput.GetName())
 	klog.V(1).Infof(" - Read-only mode: %t", m.StaticConfig.ReadOnly)
 	klog.V(1).Infof(" - Disable destructive tools: %t", m.StaticConfig.DisableDestructive)
+	klog.V(1).Infof(" - Kustomize root: %s", m.StaticConfig.KustomizeRoot)
+	klog.V(1).Infof(" - Kustomize remote bases allowed: %t", m.StaticConfig.KustomizeAllowRemote)
 
 	if m.Version {
 		_, _ = fmt.Fprintf(m.Out, "%s\n", version.Version)
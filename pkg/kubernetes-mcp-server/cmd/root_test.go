@@ -231,6 +231,27 @@ func TestDisableDestructive(t *testing.T) {
 	})
 }
 
+func TestKustomize(t *testing.T) {
+	t.Run("defaults to disabled", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--log-level=1"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), "\" - Kustomize root: \"") ||
+			!strings.Contains(out.String(), " - Kustomize remote bases allowed: false") {
+			t.Fatalf("Expected kustomize disabled, got %s %v", out, err)
+		}
+	})
+	t.Run("set with --kustomize-root and --kustomize-allow-remote", func(t *testing.T) {
+		ioStreams, out := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--log-level=1", "--kustomize-root", "/srv/kustomizations", "--kustomize-allow-remote"})
+		if err := rootCmd.Execute(); !strings.Contains(out.String(), " - Kustomize root: /srv/kustomizations") ||
+			!strings.Contains(out.String(), " - Kustomize remote bases allowed: true") {
+			t.Fatalf("Expected kustomize root and remote bases allowed, got %s %v", out, err)
+		}
+	})
+}
+
 func TestAuthorizationURL(t *testing.T) {
 	t.Run("invalid authorization-url without protocol", func(t *testing.T) {
 		ioStreams, _ := testStream()
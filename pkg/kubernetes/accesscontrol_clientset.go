@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	authenticationv1api "k8s.io/api/authentication/v1"
 	authorizationv1api "k8s.io/api/authorization/v1"
@@ -16,7 +17,9 @@ import (
 	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 	"k8s.io/metrics/pkg/apis/metrics"
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
@@ -39,6 +42,53 @@ func (a *AccessControlClientset) DiscoveryClient() discovery.DiscoveryInterface
 	return a.discoveryClient
 }
 
+func (a *AccessControlClientset) Nodes() (corev1.NodeInterface, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Node"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	return a.delegate.CoreV1().Nodes(), nil
+}
+
+// NodesDrain returns the clientset required by k8s.io/kubectl/pkg/drain to cordon and drain Nodes, provided every kind
+// the drain helper reads or writes is allowed (Nodes, Pods, the DaemonSets owning the Pods, and the Pod Evictions)
+func (a *AccessControlClientset) NodesDrain() (kubernetes.Interface, error) {
+	for _, gvk := range []*schema.GroupVersionKind{
+		{Group: "", Version: "v1", Kind: "Node"},
+		{Group: "", Version: "v1", Kind: "Pod"},
+		{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		{Group: "policy", Version: "v1", Kind: "Eviction"},
+	} {
+		if !isAllowed(a.staticConfig, gvk) {
+			return nil, isNotAllowedError(gvk)
+		}
+	}
+	return a.delegate, nil
+}
+
+func (a *AccessControlClientset) NodesMetricses(ctx context.Context, name string, listOptions metav1.ListOptions) (*metrics.NodeMetricsList, error) {
+	gvk := &schema.GroupVersionKind{Group: metrics.GroupName, Version: metricsv1beta1api.SchemeGroupVersion.Version, Kind: "NodeMetrics"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	versionedMetrics := &metricsv1beta1api.NodeMetricsList{}
+	var err error
+	if name != "" {
+		m, err := a.metricsV1beta1.NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metrics for node %s: %w", name, err)
+		}
+		versionedMetrics.Items = []metricsv1beta1api.NodeMetrics{*m}
+	} else {
+		versionedMetrics, err = a.metricsV1beta1.NodeMetricses().List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list node metrics: %w", err)
+		}
+	}
+	convertedMetrics := &metrics.NodeMetricsList{}
+	return convertedMetrics, metricsv1beta1api.Convert_v1beta1_NodeMetricsList_To_metrics_NodeMetricsList(versionedMetrics, convertedMetrics, nil)
+}
+
 func (a *AccessControlClientset) Pods(namespace string) (corev1.PodInterface, error) {
 	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}
 	if !isAllowed(a.staticConfig, gvk) {
@@ -74,6 +124,34 @@ func (a *AccessControlClientset) PodsExec(namespace, name string, podExecOptions
 	})
 }
 
+func (a *AccessControlClientset) PodsPortForward(namespace, name string) (httpstream.Dialer, error) {
+	gvk := &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}
+	if !isAllowed(a.staticConfig, gvk) {
+		return nil, isNotAllowedError(gvk)
+	}
+	// Compute URL
+	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/portforward/portforward.go#L418-L423
+	portForwardURL := a.delegate.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("portforward").
+		URL()
+	transport, upgrader, err := spdy.RoundTripperFor(a.cfg)
+	if err != nil {
+		return nil, err
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", portForwardURL)
+	webSocketDialer, err := portforward.NewSPDYOverWebsocketDialer(portForwardURL, a.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return portforward.NewFallbackDialer(webSocketDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
+
 func (a *AccessControlClientset) PodsMetricses(ctx context.Context, namespace, name string, listOptions metav1.ListOptions) (*metrics.PodMetricsList, error) {
 	gvk := &schema.GroupVersionKind{Group: metrics.GroupName, Version: metricsv1beta1api.SchemeGroupVersion.Version, Kind: "PodMetrics"}
 	if !isAllowed(a.staticConfig, gvk) {
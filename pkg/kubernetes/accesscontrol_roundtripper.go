@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// accessControlRoundTripper rejects the requests to the resources that are not allowed by the access control configuration
+// with a Forbidden response (same as if RBAC denied them).
+// It's used for the kubectl components (e.g. describers) that build their own clients from the rest.Config and retrieve
+// related objects by themselves, these components already handle Forbidden responses for the related objects.
+type accessControlRoundTripper struct {
+	// ctx cancels the requests when done, the kubectl components don't propagate the caller context
+	ctx          context.Context
+	delegate     http.RoundTripper
+	restMapper   meta.RESTMapper
+	staticConfig *config.StaticConfig
+}
+
+func (rt *accessControlRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rt.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if gvr, ok := resourceForPath(req.URL.Path); ok {
+		// Resources unknown to the RESTMapper are left to the server
+		if gvk, err := rt.restMapper.KindFor(gvr); err == nil && !isAllowed(rt.staticConfig, &gvk) {
+			return forbiddenResponse(req, gvr, isNotAllowedError(&gvk))
+		}
+	}
+	reqCtx, cancel := context.WithCancel(req.Context())
+	// The response body may be read after RoundTrip returns, the request is only canceled if the caller context is done
+	stop := context.AfterFunc(rt.ctx, cancel)
+	resp, err := rt.delegate.RoundTrip(req.WithContext(reqCtx))
+	if err != nil {
+		stop()
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, stop: stop, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the request context (and the callback registered on the caller context) once the
+// response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	stop   func() bool
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.stop()
+	b.cancel()
+	return err
+}
+
+// resourceForPath returns the resource of the provided Kubernetes API path
+// (/api/v1/[namespaces/<namespace>/]<resource>[/<name>[/<subresource>]] or /apis/<group>/<version>/...)
+func resourceForPath(path string) (schema.GroupVersionResource, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var gvr schema.GroupVersionResource
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		gvr.Version, segments = segments[1], segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		gvr.Group, gvr.Version, segments = segments[1], segments[2], segments[3:]
+	default:
+		return gvr, false
+	}
+	// Namespaced resources, except for the subresources of the Namespace itself
+	if len(segments) >= 3 && segments[0] == "namespaces" && segments[2] != "status" && segments[2] != "finalize" {
+		segments = segments[2:]
+	}
+	gvr.Resource = segments[0]
+	return gvr, true
+}
+
+func forbiddenResponse(req *http.Request, gvr schema.GroupVersionResource, err error) (*http.Response, error) {
+	status := apierrors.NewForbidden(gvr.GroupResource(), "", err).Status()
+	status.Kind, status.APIVersion = "Status", "v1"
+	body, marshalErr := json.Marshal(&status)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return &http.Response{
+		Status:     http.StatusText(http.StatusForbidden),
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
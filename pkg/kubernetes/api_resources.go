@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"bytes"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	explainv2 "k8s.io/kubectl/pkg/explain/v2"
+)
+
+// resourcesExplainOutputFormat is the kubectl explain builtin template used to render the OpenAPI v3 schemas
+const resourcesExplainOutputFormat = "plaintext"
+
+// APIResources returns the resources available in the cluster for their preferred group version (same as kubectl api-resources),
+// optionally restricted to the provided API group ("" matches all groups, use "core" for the legacy core group).
+// Subresources and kinds that are not allowed by the access control configuration are excluded.
+func (k *Kubernetes) APIResources(apiGroup string) ([]*metav1.APIResourceList, error) {
+	apiResourceLists, err := k.preferredAllowedAPIResources()
+	if err != nil {
+		return nil, err
+	}
+	var ret []*metav1.APIResourceList
+	for _, apiResourceList := range apiResourceLists {
+		gv, _ := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if apiGroup != "" && apiGroup != gv.Group && (apiGroup != "core" || gv.Group != "") {
+			continue
+		}
+		ret = append(ret, apiResourceList)
+	}
+	return ret, nil
+}
+
+// preferredAllowedAPIResources returns the resources discovered in the cluster for their preferred group version,
+// excluding subresources and kinds that are not allowed by the access control configuration (lists without resources are omitted)
+func (k *Kubernetes) preferredAllowedAPIResources() ([]*metav1.APIResourceList, error) {
+	apiResourceLists, err := k.manager.discoveryClient.ServerPreferredResources()
+	// Discovery may partially fail (e.g. unavailable aggregated APIs), the available groups are still usable
+	if err != nil && len(apiResourceLists) == 0 {
+		return nil, err
+	}
+	var allowed []*metav1.APIResourceList
+	for _, apiResourceList := range apiResourceLists {
+		gv, gvErr := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if gvErr != nil {
+			continue
+		}
+		allowedList := &metav1.APIResourceList{TypeMeta: apiResourceList.TypeMeta, GroupVersion: apiResourceList.GroupVersion}
+		for _, apiResource := range apiResourceList.APIResources {
+			gvk := gv.WithKind(apiResource.Kind)
+			if strings.Contains(apiResource.Name, "/") || !isAllowed(k.manager.staticConfig, &gvk) {
+				continue
+			}
+			allowedList.APIResources = append(allowedList.APIResources, apiResource)
+		}
+		if len(allowedList.APIResources) > 0 {
+			allowed = append(allowed, allowedList)
+		}
+	}
+	return allowed, nil
+}
+
+// ResourcesExplain returns the documentation of the kind, or of the field in the provided path (e.g. spec.template.spec.containers),
+// rendered from the OpenAPI v3 schema published by the cluster (same as kubectl explain).
+func (k *Kubernetes) ResourcesExplain(gvk *schema.GroupVersionKind, fieldPath string, recursive bool) (string, error) {
+	mapping, err := k.manager.accessControlRESTMapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
+	if err != nil {
+		return "", err
+	}
+	var fieldsPath []string
+	if fieldPath = strings.Trim(fieldPath, "."); fieldPath != "" {
+		fieldsPath = strings.Split(fieldPath, ".")
+	}
+	buf := new(bytes.Buffer)
+	err = explainv2.PrintModelDescription(fieldsPath, buf, k.manager.discoveryClient.OpenAPIV3(), mapping.Resource, recursive, resourcesExplainOutputFormat)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,195 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrKustomizeDisabled is returned when no kustomize root directory is configured
+var ErrKustomizeDisabled = errors.New("kustomize is disabled, no kustomize root directory is configured")
+
+// KustomizeBuild renders the kustomization in the provided directory and returns the resulting manifests (same as kubectl kustomize).
+// The directory (absolute or relative to the configured kustomize root) and the local bases it references must be within
+// the kustomize root, remote bases and resources (git or http URLs) are rejected unless they are allowed by the configuration.
+func (k *Kubernetes) KustomizeBuild(path string) (string, error) {
+	root, err := kustomizeRoot(k.manager.staticConfig.KustomizeRoot)
+	if err != nil {
+		return "", err
+	}
+	dir, err := kustomizeResolve(root, path)
+	if err != nil {
+		return "", err
+	}
+	if !k.manager.staticConfig.KustomizeAllowRemote {
+		if err = kustomizeCheckReferences(root, dir, map[string]bool{}); err != nil {
+			return "", err
+		}
+	}
+	// Same options as kubectl apply -k (plugins disabled, files restricted to the kustomization root, legacy resource order)
+	kustomizeOptions := krusty.MakeDefaultOptions()
+	kustomizeOptions.Reorder = krusty.ReorderOptionLegacy
+	resMap, err := krusty.MakeKustomizer(kustomizeOptions).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return "", err
+	}
+	manifests, err := resMap.AsYaml()
+	if err != nil {
+		return "", err
+	}
+	return string(manifests), nil
+}
+
+// KustomizeApply renders the kustomization in the provided directory (same restrictions as KustomizeBuild) and creates or updates
+// the resulting resources with server-side apply (same as kubectl apply -k --server-side)
+func (k *Kubernetes) KustomizeApply(ctx context.Context, path string, options ResourcesCreateOrUpdateOptions) ([]*unstructured.Unstructured, error) {
+	manifests, err := k.KustomizeBuild(path)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := parseResources(manifests)
+	if err != nil {
+		return nil, err
+	}
+	return k.resourcesCreateOrUpdate(ctx, resources, options)
+}
+
+func kustomizeRoot(root string) (string, error) {
+	if root == "" {
+		return "", ErrKustomizeDisabled
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(root)
+}
+
+// kustomizeResolve returns the real path of the directory, which must be within the kustomize root
+func kustomizeResolve(root, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	if rel, relErr := filepath.Rel(root, resolved); relErr != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of the kustomize root directory %s", path, root)
+	}
+	return resolved, nil
+}
+
+// kustomizeCheckReferences checks that the kustomization in the directory, and the ones in the local bases and components it
+// references (recursively), don't reference remote bases or files, and that the local bases are within the kustomize root.
+// Entries that aren't local files or directories would be cloned from git by kustomize, and http(s) URLs would be downloaded.
+func kustomizeCheckReferences(root, dir string, visited map[string]bool) error {
+	if visited[dir] {
+		return nil
+	}
+	visited[dir] = true
+	kustomization, err := kustomizeRead(dir)
+	if err != nil || kustomization == nil {
+		// Missing or invalid kustomizations are reported by kustomize
+		return err
+	}
+	kustomization.FixKustomization()
+	var directories, files []string
+	// Generators, transformers and validators may also be kustomization directories, or inline configurations
+	for _, references := range [][]string{kustomization.Resources, kustomization.Components,
+		kustomization.Generators, kustomization.Transformers, kustomization.Validators} {
+		for _, reference := range references {
+			if !strings.Contains(reference, "\n") {
+				directories = append(directories, reference)
+			}
+		}
+	}
+	files = append(files, kustomization.Crds...)
+	files = append(files, kustomization.Configurations...)
+	files = append(files, kustomization.OpenAPI["path"])
+	for _, patch := range append(kustomization.Patches, kustomization.PatchesJson6902...) {
+		files = append(files, patch.Path)
+	}
+	for _, patch := range kustomization.PatchesStrategicMerge {
+		files = append(files, string(patch))
+	}
+	for _, replacement := range kustomization.Replacements {
+		files = append(files, replacement.Path)
+	}
+	var generatorArgs []types.GeneratorArgs
+	for _, generator := range kustomization.ConfigMapGenerator {
+		generatorArgs = append(generatorArgs, generator.GeneratorArgs)
+	}
+	for _, generator := range kustomization.SecretGenerator {
+		generatorArgs = append(generatorArgs, generator.GeneratorArgs)
+	}
+	for _, generator := range generatorArgs {
+		for _, fileSource := range generator.FileSources {
+			// [{key}=]{path}
+			_, file, found := strings.Cut(fileSource, "=")
+			if !found {
+				file = fileSource
+			}
+			files = append(files, file)
+		}
+		files = append(files, generator.EnvSources...)
+	}
+	for _, file := range files {
+		if kustomizeIsRemoteFile(file) {
+			return fmt.Errorf("remote file %s referenced in %s is not allowed, remote kustomize bases and resources are disabled", file, dir)
+		}
+	}
+	for _, reference := range directories {
+		if kustomizeIsRemoteFile(reference) {
+			return fmt.Errorf("remote resource %s referenced in %s is not allowed, remote kustomize bases and resources are disabled", reference, dir)
+		}
+		info, statErr := os.Stat(filepath.Join(dir, reference))
+		if statErr != nil {
+			// Kustomize would try to clone it from a git repository
+			return fmt.Errorf("%s referenced in %s is not a local file or directory, remote kustomize bases and resources are disabled", reference, dir)
+		}
+		if !info.IsDir() {
+			continue
+		}
+		base, resolveErr := kustomizeResolve(root, filepath.Join(dir, reference))
+		if resolveErr != nil {
+			return resolveErr
+		}
+		if err = kustomizeCheckReferences(root, base, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kustomizeRead reads the kustomization file in the directory (nil if there is none)
+func kustomizeRead(dir string) (*types.Kustomization, error) {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		kustomization := &types.Kustomization{}
+		if err = yaml.Unmarshal(data, kustomization); err != nil {
+			return nil, nil
+		}
+		return kustomization, nil
+	}
+	return nil, nil
+}
+
+func kustomizeIsRemoteFile(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
@@ -0,0 +1,203 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kubectl/pkg/drain"
+	"k8s.io/metrics/pkg/apis/metrics"
+	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// DefaultNodesDrainTimeout is the maximum time to wait for the Pods to be evicted when no timeout is provided
+const DefaultNodesDrainTimeout = 60 * time.Second
+
+type NodesDrainOptions struct {
+	// IgnoreDaemonSets skips the DaemonSet-managed Pods instead of failing (same as kubectl drain --ignore-daemonsets)
+	IgnoreDaemonSets bool
+	// DeleteEmptyDirData evicts the Pods using emptyDir volumes, whose data is lost (same as kubectl drain --delete-emptydir-data)
+	DeleteEmptyDirData bool
+	// Force evicts the Pods that aren't managed by a controller, and won't be recreated (same as kubectl drain --force)
+	Force bool
+	// GracePeriodSeconds overrides the Pod termination grace period (Pod default if nil)
+	GracePeriodSeconds *int64
+	// PodSelector restricts the Pods to evict to the ones matching the label selector
+	PodSelector string
+	// Timeout is the maximum time to wait for the Pods to be evicted (DefaultNodesDrainTimeout if 0)
+	Timeout time.Duration
+	// DryRun only lists the Pods that would be evicted, the Node is not cordoned
+	DryRun bool
+}
+
+type NodesDrainResult struct {
+	// Cordoned is true if the Node was marked unschedulable by the drain (false if it was already unschedulable)
+	Cordoned bool
+	// Pods to be evicted from the Node
+	Pods []v1.Pod
+	// Evicted are the Pods that were evicted and have terminated (empty in dry run)
+	Evicted []v1.Pod
+	// Warnings about the Pods that are ignored (DaemonSets) or evicted despite not being recreated or losing data
+	Warnings string
+}
+
+type NodesTopOptions struct {
+	metav1.ListOptions
+	Name string
+}
+
+// NodesTop returns the resource consumption of the Nodes along with their allocatable resources (keyed by Node name)
+func (k *Kubernetes) NodesTop(ctx context.Context, options NodesTopOptions) (*metrics.NodeMetricsList, map[string]v1.ResourceList, error) {
+	// TODO, maybe move to mcp Tools setup and omit in case metrics aren't available in the target cluster
+	if !k.supportsGroupVersion(metrics.GroupName + "/" + metricsv1beta1api.SchemeGroupVersion.Version) {
+		return nil, nil, errors.New("metrics API is not available")
+	}
+	nodeMetrics, err := k.manager.accessControlClientSet.NodesMetricses(ctx, options.Name, options.ListOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes, err := k.manager.accessControlClientSet.Nodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	allocatable := make(map[string]v1.ResourceList)
+	if options.Name != "" {
+		node, err := nodes.Get(ctx, options.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		allocatable[node.Name] = node.Status.Allocatable
+	} else {
+		nodeList, err := nodes.List(ctx, options.ListOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, node := range nodeList.Items {
+			allocatable[node.Name] = node.Status.Allocatable
+		}
+	}
+	return nodeMetrics, allocatable, nil
+}
+
+// NodesCordon marks the Node as unschedulable (cordon) or schedulable (uncordon) and returns whether it was changed.
+// Nothing is changed if dryRun is set.
+func (k *Kubernetes) NodesCordon(ctx context.Context, name string, unschedulable bool, dryRun bool) (bool, error) {
+	nodes, err := k.manager.accessControlClientSet.Nodes()
+	if err != nil {
+		return false, err
+	}
+	node, err := nodes.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return false, nil
+	}
+	if dryRun {
+		return true, nil
+	}
+	// Same patch as kubectl cordon/uncordon
+	patch := fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)
+	_, err = nodes.Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	return true, err
+}
+
+// NodesDrain cordons the Node and evicts its Pods (same as kubectl drain). Pods are evicted through the Eviction API
+// (honoring PodDisruptionBudgets, evictions blocked by a budget are retried until the timeout expires).
+// If any of the Pods can't be evicted without IgnoreDaemonSets, DeleteEmptyDirData or Force, no Pod is evicted and the Node is left cordoned.
+func (k *Kubernetes) NodesDrain(ctx context.Context, name string, options NodesDrainOptions) (*NodesDrainResult, error) {
+	client, err := k.manager.accessControlClientSet.NodesDrain()
+	if err != nil {
+		return nil, err
+	}
+	node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultNodesDrainTimeout
+	}
+	result := &NodesDrainResult{}
+	errOut := &nodesDrainErrOut{}
+	var evictedLock sync.Mutex
+	helper := &drain.Helper{
+		Ctx:                 ctx,
+		Client:              client,
+		Force:               options.Force,
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: options.IgnoreDaemonSets,
+		DeleteEmptyDirData:  options.DeleteEmptyDirData,
+		PodSelector:         options.PodSelector,
+		Timeout:             options.Timeout,
+		Out:                 io.Discard,
+		ErrOut:              errOut,
+		// Invoked concurrently for each of the evicted Pods
+		OnPodDeletionOrEvictionFinished: func(pod *v1.Pod, _ bool, err error) {
+			if err != nil {
+				return
+			}
+			evictedLock.Lock()
+			defer evictedLock.Unlock()
+			result.Evicted = append(result.Evicted, *pod)
+		},
+	}
+	if options.GracePeriodSeconds != nil {
+		helper.GracePeriodSeconds = int(*options.GracePeriodSeconds)
+	}
+	if !options.DryRun && !node.Spec.Unschedulable {
+		if err = drain.RunCordonOrUncordon(helper, node, true); err != nil {
+			return nil, err
+		}
+		result.Cordoned = true
+	}
+	list, errs := helper.GetPodsForDeletion(name)
+	if errs != nil {
+		return result, utilerrors.NewAggregate(errs)
+	}
+	result.Pods = list.Pods()
+	result.Warnings = list.Warnings()
+	if options.DryRun || len(result.Pods) == 0 {
+		return result, nil
+	}
+	if err = helper.DeleteOrEvictPods(result.Pods); err != nil {
+		if retries := errOut.String(); retries != "" {
+			return result, fmt.Errorf("%w\n%s", err, retries)
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// nodesDrainErrOut collects the distinct messages the drain helper writes when it retries an eviction
+// (e.g. blocked by a PodDisruptionBudget), the same message is written every few seconds
+type nodesDrainErrOut struct {
+	lock     sync.Mutex
+	messages []string
+}
+
+func (o *nodesDrainErrOut) Write(p []byte) (int, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	message := strings.TrimSpace(string(p))
+	for _, m := range o.messages {
+		if m == message {
+			return len(p), nil
+		}
+	}
+	o.messages = append(o.messages, message)
+	return len(p), nil
+}
+
+func (o *nodesDrainErrOut) String() string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return strings.Join(o.messages, "\n")
+}
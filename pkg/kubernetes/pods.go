@@ -1,26 +1,124 @@
 package kubernetes
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	labelutil "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/remotecommand"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/client-go/util/exec"
+	"k8s.io/kubectl/pkg/util/podutils"
 	"k8s.io/metrics/pkg/apis/metrics"
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/version"
 )
 
+// DefaultPodsLogTailLines is the number of lines retrieved from the end of the logs when no explicit tail is requested
+const DefaultPodsLogTailLines = int64(256)
+
+// DefaultPodsLogFollowDuration is the maximum time the log stream is kept open when following the logs with no explicit duration
+const DefaultPodsLogFollowDuration = 30 * time.Second
+
+// podsLogFollowMaxLineSize is the maximum size of a single log line when following the logs
+const podsLogFollowMaxLineSize = 1024 * 1024
+
+type PodsDeleteOptions struct {
+	// Name of the Pod to delete (mutually exclusive with LabelSelector)
+	Name          string
+	LabelSelector string
+	// GracePeriodSeconds overrides the Pod termination grace period (Pod default if nil)
+	GracePeriodSeconds *int64
+	// Force deletes the Pods immediately (grace period of 0) without waiting for confirmation that they were terminated
+	Force bool
+	// Evict uses the Eviction API so that PodDisruptionBudgets are honored
+	Evict bool
+	// DryRun only lists the Pods that would be affected
+	DryRun bool
+}
+
+type PodsLogOptions struct {
+	v1.PodLogOptions
+}
+
+// podLogOptions returns the PodLogOptions to request, retrieving DefaultPodsLogTailLines if no explicit tail is requested
+func (o PodsLogOptions) podLogOptions() v1.PodLogOptions {
+	logOptions := o.PodLogOptions
+	if logOptions.TailLines == nil {
+		tailLines := DefaultPodsLogTailLines
+		logOptions.TailLines = &tailLines
+	} else if *logOptions.TailLines < 0 {
+		// Negative tail means all the available lines
+		logOptions.TailLines = nil
+	}
+	return logOptions
+}
+
+type PodsLogFollowOptions struct {
+	PodsLogOptions
+	// Duration is the maximum time to keep the log stream open (DefaultPodsLogFollowDuration if 0)
+	Duration time.Duration
+	// MaxLines is the maximum number of lines to receive before closing the log stream (no limit if 0)
+	MaxLines int
+	// OnLine is invoked for every line received while the log stream is open
+	OnLine func(line string)
+}
+
+type PodsExecOptions struct {
+	Container string
+	Command   []string
+	// Stdin is the optional content to be sent to the standard input of the command
+	Stdin string
+	// Timeout is the maximum duration of the command execution (no timeout if 0)
+	Timeout time.Duration
+}
+
+type PodsExecResult struct {
+	ExitCode int    `json:"exitCode"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+}
+
+type PodsRunOptions struct {
+	Image string
+	// Port is the TCP/IP port to expose from the Pod container (no port exposed if 0)
+	Port int32
+	// Command overrides the container image entrypoint
+	Command []string
+	// Args overrides the container image command arguments
+	Args               []string
+	Env                map[string]string
+	Resources          v1.ResourceRequirements
+	RestartPolicy      v1.RestartPolicy
+	ServiceAccountName string
+	// Labels are added to the Pod in addition to the labels managed by the server
+	Labels map[string]string
+	// ImagePullPolicy of the container (defaults to Always)
+	ImagePullPolicy v1.PullPolicy
+}
+
 type PodsTopOptions struct {
 	metav1.ListOptions
 	AllNamespaces bool
@@ -46,36 +144,125 @@ func (k *Kubernetes) PodsGet(ctx context.Context, namespace, name string) (*unst
 	}, k.NamespaceOrDefault(namespace), name)
 }
 
-func (k *Kubernetes) PodsDelete(ctx context.Context, namespace, name string) (string, error) {
+// PodsDelete deletes the Pod with the provided name, or the Pods matching the provided label selector.
+// Pods are evicted through the Eviction API (honoring PodDisruptionBudgets) if options.Evict is set, and only listed if options.DryRun is set.
+func (k *Kubernetes) PodsDelete(ctx context.Context, namespace string, options PodsDeleteOptions) (string, error) {
 	namespace = k.NamespaceOrDefault(namespace)
-	pod, err := k.ResourcesGet(ctx, &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}, namespace, name)
+	if options.Name == "" && options.LabelSelector == "" {
+		return "", errors.New("name or label selector is required")
+	}
+	if options.Name != "" && options.LabelSelector != "" {
+		return "", errors.New("name and label selector can't be combined")
+	}
+	if options.Force {
+		if options.Evict {
+			return "", errors.New("force can't be combined with evict, forced deletions bypass PodDisruptionBudgets")
+		}
+		if options.GracePeriodSeconds != nil && *options.GracePeriodSeconds > 0 {
+			return "", errors.New("force can't be combined with a grace period greater than 0")
+		}
+		options.GracePeriodSeconds = ptr.To(int64(0))
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
 	if err != nil {
 		return "", err
 	}
+	var targets []v1.Pod
+	if options.Name != "" {
+		pod, err := pods.Get(ctx, options.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		targets = append(targets, *pod)
+	} else if targets, err = k.podsForSelector(ctx, namespace, options.LabelSelector); err != nil {
+		return "", err
+	} else if len(targets) == 0 {
+		return fmt.Sprintf("No pods found matching label selector %s in namespace %s", options.LabelSelector, namespace), nil
+	}
 
-	isManaged := pod.GetLabels()[AppKubernetesManagedBy] == version.BinaryName
+	// Pods created by pods_run are deleted along with their Service, deleting them requires access to Services too
+	var services corev1.ServiceInterface
+	for _, pod := range targets {
+		if pod.GetLabels()[AppKubernetesManagedBy] == version.BinaryName {
+			if services, err = k.manager.accessControlClientSet.Services(namespace); err != nil {
+				return "", err
+			}
+			break
+		}
+	}
+
+	action := "deleted"
+	if options.Evict {
+		action = "evicted"
+	}
+	if options.DryRun {
+		ret := fmt.Sprintf("# The following %d Pods in namespace %s would be %s (dry run)\n", len(targets), namespace, action)
+		for _, pod := range targets {
+			ret += fmt.Sprintf("- %s (phase: %s, node: %s)\n", pod.Name, pod.Status.Phase, pod.Spec.NodeName)
+		}
+		return ret, nil
+	}
+
+	deleteOptions := metav1.DeleteOptions{GracePeriodSeconds: options.GracePeriodSeconds}
+	var succeeded, failed []string
+	for _, pod := range targets {
+		if options.Evict {
+			err = pods.EvictV1(ctx, &policyv1.Eviction{
+				ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: namespace},
+				DeleteOptions: &deleteOptions,
+			})
+			if apierrors.IsTooManyRequests(err) {
+				err = fmt.Errorf("blocked by PodDisruptionBudget: %w", err)
+			}
+		} else {
+			err = pods.Delete(ctx, pod.Name, deleteOptions)
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("- %s: %v", pod.Name, err))
+			continue
+		}
+		succeeded = append(succeeded, "- "+pod.Name)
+		k.podsDeleteManagedResources(ctx, namespace, services, &pod)
+	}
+	// Single named Pod
+	if options.Name != "" {
+		if len(failed) > 0 {
+			return "", err
+		}
+		return "Pod " + action + " successfully", nil
+	}
+	ret := fmt.Sprintf("# %d Pods %s in namespace %s\n", len(succeeded), action, namespace)
+	if len(succeeded) > 0 {
+		ret += strings.Join(succeeded, "\n") + "\n"
+	}
+	if len(failed) > 0 {
+		ret += fmt.Sprintf("# %d Pods failed to be %s\n%s\n", len(failed), action, strings.Join(failed, "\n"))
+		return ret, fmt.Errorf("%d of %d pods failed to be %s", len(failed), len(targets), action)
+	}
+	return ret, nil
+}
+
+// podsDeleteManagedResources deletes the Service and Route created alongside the Pod if it's managed by the server (pods_run)
+func (k *Kubernetes) podsDeleteManagedResources(ctx context.Context, namespace string, services corev1.ServiceInterface, pod *v1.Pod) {
+	if pod.GetLabels()[AppKubernetesManagedBy] != version.BinaryName {
+		return
+	}
 	managedLabelSelector := labelutil.Set{
 		AppKubernetesManagedBy: version.BinaryName,
 		AppKubernetesName:      pod.GetLabels()[AppKubernetesName],
 	}.AsSelector()
 
 	// Delete managed service
-	if isManaged {
-		services, err := k.manager.accessControlClientSet.Services(namespace)
-		if err != nil {
-			return "", err
-		}
-		if sl, _ := services.List(ctx, metav1.ListOptions{
-			LabelSelector: managedLabelSelector.String(),
-		}); sl != nil {
-			for _, svc := range sl.Items {
-				_ = services.Delete(ctx, svc.Name, metav1.DeleteOptions{})
-			}
+	if sl, _ := services.List(ctx, metav1.ListOptions{
+		LabelSelector: managedLabelSelector.String(),
+	}); sl != nil {
+		for _, svc := range sl.Items {
+			_ = services.Delete(ctx, svc.Name, metav1.DeleteOptions{})
 		}
 	}
 
 	// Delete managed Route
-	if isManaged && k.supportsGroupVersion("route.openshift.io/v1") {
+	if k.supportsGroupVersion("route.openshift.io/v1") {
 		routeResources := k.manager.dynamicClient.
 			Resource(schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}).
 			Namespace(namespace)
@@ -86,22 +273,16 @@ func (k *Kubernetes) PodsDelete(ctx context.Context, namespace, name string) (st
 				_ = routeResources.Delete(ctx, route.GetName(), metav1.DeleteOptions{})
 			}
 		}
-
 	}
-	return "Pod deleted successfully",
-		k.ResourcesDelete(ctx, &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}, namespace, name)
 }
 
-func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name, container string) (string, error) {
-	tailLines := int64(256)
+func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name string, options PodsLogOptions) (string, error) {
+	logOptions := options.podLogOptions()
 	pods, err := k.manager.accessControlClientSet.Pods(k.NamespaceOrDefault(namespace))
 	if err != nil {
 		return "", err
 	}
-	req := pods.GetLogs(name, &v1.PodLogOptions{
-		TailLines: &tailLines,
-		Container: container,
-	})
+	req := pods.GetLogs(name, &logOptions)
 	res := req.Do(ctx)
 	if res.Error() != nil {
 		return "", res.Error()
@@ -113,7 +294,47 @@ func (k *Kubernetes) PodsLog(ctx context.Context, namespace, name, container str
 	return string(rawData), nil
 }
 
-func (k *Kubernetes) PodsRun(ctx context.Context, namespace, name, image string, port int32) ([]*unstructured.Unstructured, error) {
+// PodsLogFollow streams the logs of the Pod (equivalent to kubectl logs --follow) until the follow duration elapses,
+// the maximum number of lines is received, or the container terminates, and returns the received logs.
+func (k *Kubernetes) PodsLogFollow(ctx context.Context, namespace, name string, options PodsLogFollowOptions) (string, error) {
+	logOptions := options.podLogOptions()
+	logOptions.Follow = true
+	duration := options.Duration
+	if duration <= 0 {
+		duration = DefaultPodsLogFollowDuration
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(k.NamespaceOrDefault(namespace))
+	if err != nil {
+		return "", err
+	}
+	followCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	stream, err := pods.GetLogs(name, &logOptions).Stream(followCtx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = stream.Close() }()
+	logs := strings.Builder{}
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), podsLogFollowMaxLineSize)
+	for lines := 0; scanner.Scan(); {
+		line := scanner.Text()
+		logs.WriteString(line + "\n")
+		if options.OnLine != nil {
+			options.OnLine(line)
+		}
+		if lines++; options.MaxLines > 0 && lines >= options.MaxLines {
+			break
+		}
+	}
+	// Reaching the follow duration interrupts the stream, which is the expected way to stop following the logs
+	if err = scanner.Err(); err != nil && followCtx.Err() == nil {
+		return logs.String(), err
+	}
+	return logs.String(), nil
+}
+
+func (k *Kubernetes) PodsRun(ctx context.Context, namespace, name string, options PodsRunOptions) ([]*unstructured.Unstructured, error) {
 	if name == "" {
 		name = version.BinaryName + "-run-" + rand.String(5)
 	}
@@ -123,16 +344,37 @@ func (k *Kubernetes) PodsRun(ctx context.Context, namespace, name, image string,
 		AppKubernetesManagedBy: version.BinaryName,
 		AppKubernetesPartOf:    version.BinaryName + "-run-sandbox",
 	}
+	// The server-managed labels take precedence over the provided ones (used as Service selector)
+	podLabels := make(map[string]string, len(options.Labels)+len(labels))
+	for key, value := range options.Labels {
+		podLabels[key] = value
+	}
+	for key, value := range labels {
+		podLabels[key] = value
+	}
+	imagePullPolicy := options.ImagePullPolicy
+	if imagePullPolicy == "" {
+		imagePullPolicy = v1.PullAlways
+	}
+	port := options.Port
 	// NewPod
 	var resources []any
 	pod := &v1.Pod{
 		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
-		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k.NamespaceOrDefault(namespace), Labels: labels},
-		Spec: v1.PodSpec{Containers: []v1.Container{{
-			Name:            name,
-			Image:           image,
-			ImagePullPolicy: v1.PullAlways,
-		}}},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k.NamespaceOrDefault(namespace), Labels: podLabels},
+		Spec: v1.PodSpec{
+			RestartPolicy:      options.RestartPolicy,
+			ServiceAccountName: options.ServiceAccountName,
+			Containers: []v1.Container{{
+				Name:            name,
+				Image:           options.Image,
+				ImagePullPolicy: imagePullPolicy,
+				Command:         options.Command,
+				Args:            options.Args,
+				Env:             podsRunEnv(options.Env),
+				Resources:       options.Resources,
+			}},
+		},
 	}
 	resources = append(resources, pod)
 	if port > 0 {
@@ -190,7 +432,90 @@ func (k *Kubernetes) PodsRun(ctx context.Context, namespace, name, image string,
 		}
 		toCreate = append(toCreate, u)
 	}
-	return k.resourcesCreateOrUpdate(ctx, toCreate)
+	return k.resourcesCreateOrUpdate(ctx, toCreate, ResourcesCreateOrUpdateOptions{})
+}
+
+// PodsWait blocks until the Pod is Ready, has terminated, or its containers can't be started, and returns the Pod.
+// Returns an error if the Pod doesn't reach any of these states within the provided timeout.
+func (k *Kubernetes) PodsWait(ctx context.Context, namespace, name string, timeout time.Duration) (*v1.Pod, error) {
+	return k.podsWaitUntil(ctx, namespace, name, timeout, "be ready or terminated", podsWaitCondition)
+}
+
+// podsWaitUntil watches the Pod until the provided condition is satisfied or the timeout expires
+func (k *Kubernetes) podsWaitUntil(ctx context.Context, namespace, name string, timeout time.Duration, description string, condition watchtools.ConditionFunc) (*v1.Pod, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, err
+	}
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	listWatch := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return pods.List(ctx, options)
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return pods.Watch(ctx, options)
+		},
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	event, err := watchtools.UntilWithSync(waitCtx, listWatch, &v1.Pod{}, nil, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Deleted {
+			return false, errors.New("pod was deleted")
+		}
+		return condition(event)
+	})
+	if err != nil {
+		if waitCtx.Err() != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("timed out after %s waiting for pod %s to %s", timeout, name, description)
+		}
+		return nil, err
+	}
+	return event.Object.(*v1.Pod), nil
+}
+
+// podsWaitCondition is satisfied once the Pod is Ready, has terminated, or is stuck due to a container error
+func podsWaitCondition(event watch.Event) (bool, error) {
+	pod, ok := event.Object.(*v1.Pod)
+	if !ok {
+		return false, nil
+	}
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed || podutils.IsPodReady(pod) {
+		return true, nil
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if podsContainerStuck(containerStatus) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// podsContainerStuck returns true if the container is waiting due to an error that requires user intervention
+func podsContainerStuck(containerStatus v1.ContainerStatus) bool {
+	if containerStatus.State.Waiting == nil {
+		return false
+	}
+	switch containerStatus.State.Waiting.Reason {
+	case "CrashLoopBackOff", "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+		return true
+	}
+	return false
+}
+
+// podsRunEnv converts the provided environment variables to a list sorted by name (deterministic Pod spec)
+func podsRunEnv(env map[string]string) []v1.EnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+	ret := make([]v1.EnvVar, 0, len(env))
+	for name, value := range env {
+		ret = append(ret, v1.EnvVar{Name: name, Value: value})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
 }
 
 func (k *Kubernetes) PodsTop(ctx context.Context, options PodsTopOptions) (*metrics.PodMetricsList, error) {
@@ -207,19 +532,45 @@ func (k *Kubernetes) PodsTop(ctx context.Context, options PodsTopOptions) (*metr
 	return k.manager.accessControlClientSet.PodsMetricses(ctx, namespace, options.Name, options.ListOptions)
 }
 
-func (k *Kubernetes) PodsExec(ctx context.Context, namespace, name, container string, command []string) (string, error) {
+func (k *Kubernetes) PodsExec(ctx context.Context, namespace, name string, options PodsExecOptions) (*PodsExecResult, error) {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	var stdin io.Reader
+	if options.Stdin != "" {
+		stdin = strings.NewReader(options.Stdin)
+	}
+	stdout := bytes.NewBuffer(make([]byte, 0))
+	stderr := bytes.NewBuffer(make([]byte, 0))
+	err := k.podsExecStream(ctx, namespace, name, options.Container, options.Command, stdin, stdout, stderr)
+	result := &PodsExecResult{Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitStatus()
+		return result, nil
+	}
+	if err != nil && options.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return result, fmt.Errorf("command timed out after %s", options.Timeout)
+	}
+	return result, err
+}
+
+// podsExecStream executes the command in the provided Pod container streaming the provided stdin, stdout, and stderr
+func (k *Kubernetes) podsExecStream(ctx context.Context, namespace, name, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error {
 	namespace = k.NamespaceOrDefault(namespace)
 	pods, err := k.manager.accessControlClientSet.Pods(namespace)
 	if err != nil {
-		return "", err
+		return err
 	}
 	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return "", err
+		return err
 	}
 	// https://github.com/kubernetes/kubectl/blob/5366de04e168bcbc11f5e340d131a9ca8b7d0df4/pkg/cmd/exec/exec.go#L350-L352
 	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
-		return "", fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
+		return fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", pod.Status.Phase)
 	}
 	if container == "" {
 		container = pod.Spec.Containers[0].Name
@@ -227,25 +578,15 @@ func (k *Kubernetes) PodsExec(ctx context.Context, namespace, name, container st
 	podExecOptions := &v1.PodExecOptions{
 		Container: container,
 		Command:   command,
-		Stdout:    true,
-		Stderr:    true,
+		Stdin:     stdin != nil,
+		Stdout:    stdout != nil,
+		Stderr:    stderr != nil,
 	}
 	executor, err := k.manager.accessControlClientSet.PodsExec(namespace, name, podExecOptions)
 	if err != nil {
-		return "", err
+		return err
 	}
-	stdout := bytes.NewBuffer(make([]byte, 0))
-	stderr := bytes.NewBuffer(make([]byte, 0))
-	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: stdout, Stderr: stderr, Tty: false,
-	}); err != nil {
-		return "", err
-	}
-	if stdout.Len() > 0 {
-		return stdout.String(), nil
-	}
-	if stderr.Len() > 0 {
-		return stderr.String(), nil
-	}
-	return "", nil
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin: stdin, Stdout: stdout, Stderr: stderr, Tty: false,
+	})
 }
@@ -0,0 +1,132 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/util/exec"
+)
+
+// ErrPodsCopyMaxSize is returned when the copied content exceeds the maximum allowed size
+var ErrPodsCopyMaxSize = errors.New("content exceeds the maximum allowed size")
+
+type PodsCopyFile struct {
+	// Path of the file in the Pod container
+	Path    string
+	Mode    int64
+	ModTime time.Time
+	Content []byte
+}
+
+// PodsCopyFrom retrieves the file or directory in the provided path of the Pod container (equivalent to kubectl cp).
+// The content is streamed as a tar archive through the exec subresource and is limited to maxSize bytes.
+func (k *Kubernetes) PodsCopyFrom(ctx context.Context, namespace, name, container, filePath string, maxSize int64) ([]PodsCopyFile, error) {
+	filePath = path.Clean(filePath)
+	if !path.IsAbs(filePath) || filePath == "/" {
+		return nil, fmt.Errorf("path %s must be an absolute file or directory path", filePath)
+	}
+	dir, base := path.Split(filePath)
+	stdout := &limitedBuffer{limit: maxSize}
+	stderr := bytes.NewBuffer(make([]byte, 0))
+	err := k.podsExecStream(ctx, namespace, name, container, []string{"tar", "cf", "-", "-C", dir, base}, nil, stdout, stderr)
+	if errors.Is(err, ErrPodsCopyMaxSize) || stdout.exceeded {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrPodsCopyMaxSize, maxSize)
+	}
+	if err != nil {
+		return nil, podsCopyError(err, stderr)
+	}
+	var files []PodsCopyFile
+	reader := tar.NewReader(&stdout.buffer)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		files = append(files, PodsCopyFile{
+			Path:    path.Join(dir, header.Name),
+			Mode:    header.Mode,
+			ModTime: header.ModTime,
+			Content: content,
+		})
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no regular files found in %s", filePath)
+	}
+	return files, nil
+}
+
+// PodsCopyTo writes the provided content into the file in the provided path of the Pod container (equivalent to kubectl cp).
+// The content is streamed as a tar archive through the exec subresource.
+func (k *Kubernetes) PodsCopyTo(ctx context.Context, namespace, name, container, filePath string, content []byte) error {
+	filePath = path.Clean(filePath)
+	if !path.IsAbs(filePath) || filePath == "/" {
+		return fmt.Errorf("path %s must be an absolute file path", filePath)
+	}
+	dir, base := path.Split(filePath)
+	archive := bytes.NewBuffer(make([]byte, 0))
+	writer := tar.NewWriter(archive)
+	if err := writer.WriteHeader(&tar.Header{
+		Name:    base,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	if _, err := writer.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	stderr := bytes.NewBuffer(make([]byte, 0))
+	err := k.podsExecStream(ctx, namespace, name, container, []string{"tar", "xmf", "-", "-C", dir}, archive, io.Discard, stderr)
+	if err != nil {
+		return podsCopyError(err, stderr)
+	}
+	return nil
+}
+
+func podsCopyError(err error, stderr *bytes.Buffer) error {
+	var exitErr exec.ExitError
+	if errors.As(err, &exitErr) {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("tar exited with code %d: %s", exitErr.ExitStatus(), message)
+		}
+		return fmt.Errorf("tar exited with code %d (tar must be available in the container)", exitErr.ExitStatus())
+	}
+	return err
+}
+
+// limitedBuffer is a buffer that fails once more than limit bytes are written.
+// The buffer is not embedded so that io.Copy can't bypass the limit through bytes.Buffer's ReadFrom.
+type limitedBuffer struct {
+	buffer   bytes.Buffer
+	limit    int64
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit > 0 && int64(b.buffer.Len()+len(p)) > b.limit {
+		b.exceeded = true
+		return 0, ErrPodsCopyMaxSize
+	}
+	return b.buffer.Write(p)
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// DefaultPodsDebugTimeout is the maximum time to wait for the debug container command to complete when no explicit timeout is requested
+const DefaultPodsDebugTimeout = 60 * time.Second
+
+type PodsDebugOptions struct {
+	Image string
+	// Target is the optional name of the Pod container whose process namespace is shared with the debug container
+	Target  string
+	Command []string
+	// Timeout is the maximum time to wait for the command to complete (DefaultPodsDebugTimeout if 0)
+	Timeout time.Duration
+}
+
+type PodsDebugResult struct {
+	Container string `json:"container"`
+	ExitCode  int    `json:"exitCode"`
+	Output    string `json:"output"`
+}
+
+// PodsDebug adds an ephemeral container running the provided command to the Pod (equivalent to kubectl debug --target),
+// waits for the command to complete, and returns its exit code and output.
+// Ephemeral containers can't be removed from a Pod, the debug container remains listed in the Pod spec once it terminates.
+func (k *Kubernetes) PodsDebug(ctx context.Context, namespace, name string, options PodsDebugOptions) (*PodsDebugResult, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	if options.Image == "" {
+		return nil, errors.New("image is required")
+	}
+	if len(options.Command) == 0 {
+		return nil, errors.New("command is required")
+	}
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultPodsDebugTimeout
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return nil, fmt.Errorf("pod %s is not running (phase: %s)", name, pod.Status.Phase)
+	}
+	if options.Target != "" && !podsHasContainer(pod, options.Target) {
+		return nil, fmt.Errorf("target container %s not found in pod %s", options.Target, name)
+	}
+	container := "debugger-" + rand.String(5)
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     container,
+			Image:                    options.Image,
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			Command:                  options.Command,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+		},
+		TargetContainerName: options.Target,
+	})
+	if _, err = pods.UpdateEphemeralContainers(ctx, name, pod, metav1.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to add ephemeral container: %w", err)
+	}
+	pod, err = k.podsWaitUntil(ctx, namespace, name, timeout, "complete debug container "+container, func(event watch.Event) (bool, error) {
+		return podsDebugCondition(event, container)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &PodsDebugResult{Container: container}
+	for _, containerStatus := range pod.Status.EphemeralContainerStatuses {
+		if containerStatus.Name == container && containerStatus.State.Terminated != nil {
+			result.ExitCode = int(containerStatus.State.Terminated.ExitCode)
+		}
+	}
+	result.Output, err = k.PodsLog(ctx, namespace, name, PodsLogOptions{PodLogOptions: v1.PodLogOptions{Container: container}})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// podsDebugCondition is satisfied once the debug container has terminated, or fails if it can't be started
+func podsDebugCondition(event watch.Event, container string) (bool, error) {
+	pod, ok := event.Object.(*v1.Pod)
+	if !ok {
+		return false, nil
+	}
+	for _, containerStatus := range pod.Status.EphemeralContainerStatuses {
+		if containerStatus.Name != container {
+			continue
+		}
+		if containerStatus.State.Terminated != nil {
+			return true, nil
+		}
+		if podsContainerStuck(containerStatus) {
+			return false, fmt.Errorf("debug container %s can't be started: %s",
+				container, strings.TrimSpace(containerStatus.State.Waiting.Reason+" "+containerStatus.State.Waiting.Message))
+		}
+	}
+	return false, nil
+}
+
+func podsHasContainer(pod *v1.Pod, container string) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/describe"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultPodsDiagnoseLogTailLines is the number of lines retrieved from the end of the previous container logs
+const DefaultPodsDiagnoseLogTailLines = int64(20)
+
+type PodsDiagnosis struct {
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Phase     v1.PodPhase `json:"phase"`
+	Reason    string      `json:"reason,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	Node      string      `json:"node,omitempty"`
+	// ProbableCauses are the human-readable explanations of why the Pod is unhealthy (empty if no problem was detected)
+	ProbableCauses []string                    `json:"probableCauses"`
+	Conditions     []PodsDiagnosisCondition    `json:"conditions,omitempty"`
+	Containers     []PodsDiagnosisContainer    `json:"containers,omitempty"`
+	Events         []PodsDiagnosisEvent        `json:"events,omitempty"`
+	PreviousLogs   []PodsDiagnosisContainerLog `json:"previousLogs,omitempty"`
+}
+
+type PodsDiagnosisCondition struct {
+	Type    v1.PodConditionType `json:"type"`
+	Status  v1.ConditionStatus  `json:"status"`
+	Reason  string              `json:"reason,omitempty"`
+	Message string              `json:"message,omitempty"`
+}
+
+type PodsDiagnosisContainer struct {
+	Name            string                       `json:"name"`
+	Init            bool                         `json:"init,omitempty"`
+	Image           string                       `json:"image"`
+	Ready           bool                         `json:"ready"`
+	RestartCount    int32                        `json:"restartCount"`
+	State           PodsDiagnosisContainerState  `json:"state"`
+	LastTermination *PodsDiagnosisContainerState `json:"lastTermination,omitempty"`
+	LivenessProbe   string                       `json:"livenessProbe,omitempty"`
+	ReadinessProbe  string                       `json:"readinessProbe,omitempty"`
+	StartupProbe    string                       `json:"startupProbe,omitempty"`
+	MemoryLimit     string                       `json:"memoryLimit,omitempty"`
+}
+
+type PodsDiagnosisContainerState struct {
+	// State of the container (Waiting, Running or Terminated)
+	State    string `json:"state"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExitCode *int32 `json:"exitCode,omitempty"`
+}
+
+type PodsDiagnosisEvent struct {
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	Count     int32  `json:"count,omitempty"`
+}
+
+type PodsDiagnosisContainerLog struct {
+	Container string `json:"container"`
+	Log       string `json:"log"`
+}
+
+// PodsDiagnose collects the Pod conditions, container states, events and previous container logs of the provided Pod
+// and summarizes the probable causes of why the Pod is unhealthy.
+// A tailLines value of 0 uses DefaultPodsDiagnoseLogTailLines.
+func (k *Kubernetes) PodsDiagnose(ctx context.Context, namespace, name string, tailLines int64) (*PodsDiagnosis, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	if tailLines <= 0 {
+		tailLines = DefaultPodsDiagnoseLogTailLines
+	}
+	pods, err := k.manager.accessControlClientSet.Pods(namespace)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := pods.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	diagnosis := &PodsDiagnosis{
+		Namespace:      pod.Namespace,
+		Name:           pod.Name,
+		Phase:          pod.Status.Phase,
+		Reason:         pod.Status.Reason,
+		Message:        pod.Status.Message,
+		Node:           pod.Spec.NodeName,
+		ProbableCauses: make([]string, 0),
+	}
+	for _, condition := range pod.Status.Conditions {
+		diagnosis.Conditions = append(diagnosis.Conditions, PodsDiagnosisCondition{
+			Type:    condition.Type,
+			Status:  condition.Status,
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+	diagnosis.Containers = append(
+		podsDiagnoseContainers(pod.Spec.InitContainers, pod.Status.InitContainerStatuses, true),
+		podsDiagnoseContainers(pod.Spec.Containers, pod.Status.ContainerStatuses, false)...)
+	// Events are best effort (may be denied or unavailable), the diagnosis is still useful without them
+	if events, eventsErr := k.podsDiagnoseEvents(ctx, pod); eventsErr == nil {
+		diagnosis.Events = events
+	}
+	for _, container := range diagnosis.Containers {
+		if container.LastTermination == nil {
+			continue
+		}
+		log, logErr := k.PodsLog(ctx, namespace, name, PodsLogOptions{PodLogOptions: v1.PodLogOptions{
+			Container: container.Name,
+			Previous:  true,
+			TailLines: ptr.To(tailLines),
+		}})
+		if logErr != nil {
+			log = fmt.Sprintf("# failed to get previous logs: %v", logErr)
+		}
+		diagnosis.PreviousLogs = append(diagnosis.PreviousLogs, PodsDiagnosisContainerLog{Container: container.Name, Log: log})
+	}
+	diagnosis.ProbableCauses = podsDiagnoseCauses(pod, diagnosis)
+	return diagnosis, nil
+}
+
+func podsDiagnoseContainers(containers []v1.Container, statuses []v1.ContainerStatus, init bool) []PodsDiagnosisContainer {
+	var ret []PodsDiagnosisContainer
+	for _, container := range containers {
+		diagnosisContainer := PodsDiagnosisContainer{
+			Name:           container.Name,
+			Init:           init,
+			Image:          container.Image,
+			State:          PodsDiagnosisContainerState{State: "Waiting", Reason: "NotCreated"},
+			LivenessProbe:  podsDiagnoseProbe(container.LivenessProbe),
+			ReadinessProbe: podsDiagnoseProbe(container.ReadinessProbe),
+			StartupProbe:   podsDiagnoseProbe(container.StartupProbe),
+		}
+		if memoryLimit, ok := container.Resources.Limits[v1.ResourceMemory]; ok {
+			diagnosisContainer.MemoryLimit = memoryLimit.String()
+		}
+		for _, status := range statuses {
+			if status.Name != container.Name {
+				continue
+			}
+			diagnosisContainer.Ready = status.Ready
+			diagnosisContainer.RestartCount = status.RestartCount
+			diagnosisContainer.State = podsDiagnoseContainerState(status.State)
+			if status.LastTerminationState.Terminated != nil {
+				lastTermination := podsDiagnoseContainerState(status.LastTerminationState)
+				diagnosisContainer.LastTermination = &lastTermination
+			}
+		}
+		ret = append(ret, diagnosisContainer)
+	}
+	return ret
+}
+
+func podsDiagnoseContainerState(state v1.ContainerState) PodsDiagnosisContainerState {
+	switch {
+	case state.Terminated != nil:
+		return PodsDiagnosisContainerState{
+			State:    "Terminated",
+			Reason:   state.Terminated.Reason,
+			Message:  strings.TrimSpace(state.Terminated.Message),
+			ExitCode: ptr.To(state.Terminated.ExitCode),
+		}
+	case state.Running != nil:
+		return PodsDiagnosisContainerState{State: "Running"}
+	case state.Waiting != nil:
+		return PodsDiagnosisContainerState{State: "Waiting", Reason: state.Waiting.Reason, Message: strings.TrimSpace(state.Waiting.Message)}
+	}
+	return PodsDiagnosisContainerState{State: "Unknown"}
+}
+
+func podsDiagnoseProbe(probe *v1.Probe) string {
+	if probe == nil {
+		return ""
+	}
+	// DescribeProbe dereferences the optional gRPC service
+	if probe.GRPC != nil && probe.GRPC.Service == nil {
+		probe = probe.DeepCopy()
+		probe.GRPC.Service = ptr.To("")
+	}
+	return describe.DescribeProbe(probe)
+}
+
+// podsDiagnoseEvents returns the events involving the provided Pod sorted by time
+func (k *Kubernetes) podsDiagnoseEvents(ctx context.Context, pod *v1.Pod) ([]PodsDiagnosisEvent, error) {
+	raw, err := k.ResourcesList(ctx, &schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Event"}, pod.Namespace, ResourceListOptions{
+		ListOptions: metav1.ListOptions{FieldSelector: fields.Set{
+			"involvedObject.kind": "Pod",
+			"involvedObject.name": pod.Name,
+		}.String()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	type timedEvent struct {
+		time  time.Time
+		event PodsDiagnosisEvent
+	}
+	var timedEvents []timedEvent
+	for _, item := range raw.(*unstructured.UnstructuredList).Items {
+		event := &v1.Event{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
+			return nil, err
+		}
+		// Skip events of previous Pods with the same name
+		if event.InvolvedObject.UID != "" && event.InvolvedObject.UID != pod.UID {
+			continue
+		}
+		timestamp := event.LastTimestamp.Time
+		if timestamp.IsZero() && event.Series != nil {
+			timestamp = event.Series.LastObservedTime.Time
+		}
+		if timestamp.IsZero() {
+			timestamp = event.EventTime.Time
+		}
+		if timestamp.IsZero() {
+			timestamp = event.FirstTimestamp.Time
+		}
+		timedEvents = append(timedEvents, timedEvent{time: timestamp, event: PodsDiagnosisEvent{
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Message:   strings.TrimSpace(event.Message),
+			Count:     event.Count,
+		}})
+	}
+	sort.SliceStable(timedEvents, func(i, j int) bool { return timedEvents[i].time.Before(timedEvents[j].time) })
+	events := make([]PodsDiagnosisEvent, 0, len(timedEvents))
+	for _, timedEvent := range timedEvents {
+		events = append(events, timedEvent.event)
+	}
+	return events, nil
+}
+
+// podsDiagnoseCauses infers the probable causes of the Pod problems from the collected diagnosis
+func podsDiagnoseCauses(pod *v1.Pod, diagnosis *PodsDiagnosis) []string {
+	causes := make([]string, 0)
+	addCause := func(format string, args ...any) {
+		cause := fmt.Sprintf(format, args...)
+		for _, existing := range causes {
+			if existing == cause {
+				return
+			}
+		}
+		causes = append(causes, cause)
+	}
+	if pod.Status.Reason == "Evicted" {
+		addCause("Evicted: the Pod was evicted from node %s: %s", pod.Spec.NodeName, pod.Status.Message)
+	}
+	for _, condition := range diagnosis.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse {
+			addCause("Unschedulable: the Pod can't be scheduled to any node: %s", condition.Message)
+		}
+	}
+	for _, container := range diagnosis.Containers {
+		switch container.State.Reason {
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ErrImageNeverPull":
+			addCause("%s: container %s can't pull image %s: %s", container.State.Reason, container.Name, container.Image, container.State.Message)
+		case "CreateContainerConfigError", "CreateContainerError":
+			addCause("%s: container %s can't be created, check that the referenced ConfigMaps and Secrets exist: %s",
+				container.State.Reason, container.Name, container.State.Message)
+		case "CrashLoopBackOff":
+			if container.LastTermination != nil && container.LastTermination.ExitCode != nil {
+				addCause("CrashLoopBackOff: container %s keeps crashing (%d restarts), last terminated with reason %s and exit code %d, check the previous logs",
+					container.Name, container.RestartCount, container.LastTermination.Reason, *container.LastTermination.ExitCode)
+			} else {
+				addCause("CrashLoopBackOff: container %s keeps crashing (%d restarts), check the previous logs", container.Name, container.RestartCount)
+			}
+		}
+		for _, state := range []*PodsDiagnosisContainerState{&container.State, container.LastTermination} {
+			if state == nil || state.ExitCode == nil {
+				continue
+			}
+			if state.Reason == "OOMKilled" {
+				memoryLimit := container.MemoryLimit
+				if memoryLimit == "" {
+					memoryLimit = "none"
+				}
+				addCause("OOMKilled: container %s was killed because it ran out of memory (memory limit: %s)", container.Name, memoryLimit)
+			} else if state == &container.State && *state.ExitCode != 0 {
+				addCause("Error: container %s terminated with exit code %d (reason: %s)", container.Name, *state.ExitCode, state.Reason)
+			}
+		}
+	}
+	for _, event := range diagnosis.Events {
+		if event.Type != v1.EventTypeWarning {
+			continue
+		}
+		switch event.Reason {
+		case "Unhealthy":
+			addCause("ProbeFailure: %s", event.Message)
+		case "FailedMount", "FailedAttachVolume":
+			addCause("%s: a volume can't be mounted, check that the referenced ConfigMaps, Secrets and PersistentVolumeClaims exist: %s", event.Reason, event.Message)
+		case "FailedCreatePodSandBox":
+			addCause("FailedCreatePodSandBox: the Pod sandbox can't be created on node %s: %s", pod.Spec.NodeName, event.Message)
+		}
+	}
+	if len(causes) == 0 && pod.Status.Phase == v1.PodRunning {
+		for _, container := range diagnosis.Containers {
+			if !container.Init && !container.Ready && container.State.State == "Running" && container.ReadinessProbe != "" {
+				addCause("NotReady: container %s is running but not ready, its readiness probe is failing: %s", container.Name, container.ReadinessProbe)
+			}
+		}
+	}
+	return causes
+}
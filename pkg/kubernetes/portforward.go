@@ -0,0 +1,222 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labelutil "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/kubectl/pkg/util"
+	"k8s.io/kubectl/pkg/util/podutils"
+)
+
+// portForwardAddress is the only local address port-forwards are bound to (never exposed to the network)
+const portForwardAddress = "localhost"
+
+// portForwardReadyTimeout is the maximum time to wait for a port-forward to be ready
+const portForwardReadyTimeout = 30 * time.Second
+
+type PortForwardOptions struct {
+	Namespace string
+	// Kind of the port-forward target (Pod or Service)
+	Kind string
+	Name string
+	// RemotePort is the Pod container port, or the Service port in case of a Service target
+	RemotePort int32
+	// LocalPort to listen on (random available port if 0)
+	LocalPort int32
+}
+
+type PortForwardSession struct {
+	ID         string    `json:"id"`
+	Namespace  string    `json:"namespace"`
+	Target     string    `json:"target"`
+	Pod        string    `json:"pod"`
+	LocalPort  int32     `json:"localPort"`
+	RemotePort int32     `json:"remotePort"`
+	Address    string    `json:"address"`
+	StartTime  time.Time `json:"startTime"`
+	stopChan   chan struct{}
+	stopOnce   sync.Once
+}
+
+func (s *PortForwardSession) stop() {
+	s.stopOnce.Do(func() { close(s.stopChan) })
+}
+
+// PortForwardSessions keeps track of the port-forwards started by the server so that they can be listed and stopped
+type PortForwardSessions struct {
+	mutex    sync.Mutex
+	sessions map[string]*PortForwardSession
+}
+
+func NewPortForwardSessions() *PortForwardSessions {
+	return &PortForwardSessions{sessions: make(map[string]*PortForwardSession)}
+}
+
+// List returns the active port-forward sessions sorted by start time
+func (p *PortForwardSessions) List() []*PortForwardSession {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	sessions := make([]*PortForwardSession, 0, len(p.sessions))
+	for _, session := range p.sessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+	return sessions
+}
+
+// Stop stops the port-forward session with the provided ID
+func (p *PortForwardSessions) Stop(id string) (*PortForwardSession, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	session, ok := p.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("port-forward session %s not found", id)
+	}
+	session.stop()
+	delete(p.sessions, id)
+	return session, nil
+}
+
+// Close stops all the active port-forward sessions
+func (p *PortForwardSessions) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for id, session := range p.sessions {
+		session.stop()
+		delete(p.sessions, id)
+	}
+}
+
+func (p *PortForwardSessions) add(session *PortForwardSession) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.sessions[session.ID] = session
+}
+
+func (p *PortForwardSessions) remove(session *PortForwardSession) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.sessions[session.ID] == session {
+		delete(p.sessions, session.ID)
+	}
+}
+
+// PortForwardStart opens a local port-forward to the provided Pod or Service and tracks it in the provided sessions
+func (k *Kubernetes) PortForwardStart(ctx context.Context, sessions *PortForwardSessions, options PortForwardOptions) (*PortForwardSession, error) {
+	namespace := k.NamespaceOrDefault(options.Namespace)
+	if options.RemotePort <= 0 {
+		return nil, errors.New("remote port must be greater than 0")
+	}
+	podName, remotePort := options.Name, options.RemotePort
+	switch options.Kind {
+	case "", "Pod":
+		options.Kind = "Pod"
+	case "Service":
+		var err error
+		if podName, remotePort, err = k.portForwardServiceTarget(ctx, namespace, options.Name, options.RemotePort); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported port-forward target kind %s, supported kinds are: Pod, Service", options.Kind)
+	}
+	dialer, err := k.manager.accessControlClientSet.PodsPortForward(namespace, podName)
+	if err != nil {
+		return nil, err
+	}
+	session := &PortForwardSession{
+		ID:         "pf-" + rand.String(5),
+		Namespace:  namespace,
+		Target:     options.Kind + "/" + options.Name,
+		Pod:        podName,
+		RemotePort: options.RemotePort,
+		Address:    portForwardAddress,
+		StartTime:  time.Now(),
+		stopChan:   make(chan struct{}),
+	}
+	readyChan := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{portForwardAddress},
+		[]string{fmt.Sprintf("%d:%d", options.LocalPort, remotePort)}, session.stopChan, readyChan, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	errChan := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		errChan <- forwarder.ForwardPorts()
+		close(done)
+		// The port-forward is no longer active (stopped or lost connection to the Pod)
+		sessions.remove(session)
+	}()
+	select {
+	case <-readyChan:
+	case err = <-errChan:
+		return nil, fmt.Errorf("failed to start port-forward: %w", err)
+	case <-time.After(portForwardReadyTimeout):
+		session.stop()
+		return nil, fmt.Errorf("failed to start port-forward: timed out after %s", portForwardReadyTimeout)
+	case <-ctx.Done():
+		session.stop()
+		return nil, ctx.Err()
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		session.stop()
+		return nil, fmt.Errorf("failed to retrieve forwarded ports: %v", err)
+	}
+	session.LocalPort = int32(ports[0].Local)
+	sessions.add(session)
+	select {
+	case <-done:
+		// The port-forward finished before it was tracked
+		sessions.remove(session)
+		return nil, fmt.Errorf("failed to start port-forward: %v", <-errChan)
+	default:
+	}
+	return session, nil
+}
+
+// portForwardServiceTarget resolves the ready Pod and container port backing the provided Service port
+func (k *Kubernetes) portForwardServiceTarget(ctx context.Context, namespace, name string, port int32) (string, int32, error) {
+	services, err := k.manager.accessControlClientSet.Services(namespace)
+	if err != nil {
+		return "", 0, err
+	}
+	service, err := services.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(service.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s has no selector", name)
+	}
+	pods, err := k.podsForSelector(ctx, namespace, labelutil.Set(service.Spec.Selector).String())
+	if err != nil {
+		return "", 0, err
+	}
+	var pod *v1.Pod
+	for i := range pods {
+		if pods[i].Status.Phase == v1.PodRunning && podutils.IsPodReady(&pods[i]) {
+			pod = &pods[i]
+			break
+		}
+	}
+	if pod == nil {
+		return "", 0, fmt.Errorf("no ready pods found for service %s", name)
+	}
+	containerPort, err := util.LookupContainerPortNumberByServicePort(*service, *pod, port)
+	if err != nil {
+		return "", 0, err
+	}
+	return pod.Name, containerPort, nil
+}
@@ -2,18 +2,30 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/version"
+	"github.com/pmezard/go-difflib/difflib"
 	authv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubectl/pkg/cmd/diff"
+	"k8s.io/kubectl/pkg/describe"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
 const (
@@ -23,11 +35,42 @@ const (
 	AppKubernetesPartOf    = "app.kubernetes.io/part-of"
 )
 
+// resourcesDescribeChunkSize is the maximum number of related objects (e.g. events) retrieved per request when describing a resource
+const resourcesDescribeChunkSize = 500
+
 type ResourceListOptions struct {
 	metav1.ListOptions
 	AsTable bool
 }
 
+type ResourcesCreateOrUpdateOptions struct {
+	// DryRun submits the resources to the server without persisting them (server-side dry-run)
+	DryRun bool
+}
+
+type ResourcesDeleteOptions struct {
+	// Name of the resource to delete (mutually exclusive with LabelSelector)
+	Name string
+	// LabelSelector to delete every matching resource (DeleteCollection)
+	LabelSelector string
+	// PropagationPolicy for the dependents of the resources (Foreground, Background or Orphan), the server default for the kind if nil
+	PropagationPolicy *metav1.DeletionPropagation
+	// GracePeriodSeconds overrides the default grace period of the resources (e.g. Pod termination grace period) if not nil
+	GracePeriodSeconds *int64
+	// Wait blocks until the resources are removed from the cluster, i.e. until their finalizers complete
+	Wait bool
+	// WaitTimeout is the maximum time to wait for the resources to be removed (DefaultResourcesWaitTimeout if 0)
+	WaitTimeout time.Duration
+}
+
+type ResourcesDeleteResult struct {
+	// Deleted are the names of the resources that were deleted
+	Deleted []string
+	// Pending are the last observed state of the resources that were not removed before the wait timeout expired
+	// (e.g. blocked by their finalizers)
+	Pending []*unstructured.Unstructured
+}
+
 func (k *Kubernetes) ResourcesList(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourceListOptions) (runtime.Unstructured, error) {
 	gvr, err := k.resourceFor(gvk)
 	if err != nil {
@@ -58,31 +101,198 @@ func (k *Kubernetes) ResourcesGet(ctx context.Context, gvk *schema.GroupVersionK
 	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
-func (k *Kubernetes) ResourcesCreateOrUpdate(ctx context.Context, resource string) ([]*unstructured.Unstructured, error) {
-	separator := regexp.MustCompile(`\r?\n---\r?\n`)
-	resources := separator.Split(resource, -1)
-	var parsedResources []*unstructured.Unstructured
-	for _, r := range resources {
-		var obj unstructured.Unstructured
-		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(r)).Decode(&obj); err != nil {
-			return nil, err
+// ResourcesDescribe returns the description of the resource as printed by kubectl describe, including its related events.
+// Kinds without a specific kubectl describer are described with the generic describer.
+// The describers retrieve the related objects (e.g. events, Pods) through a client restricted by the access control configuration.
+func (k *Kubernetes) ResourcesDescribe(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (string, error) {
+	mapping, err := k.manager.accessControlRESTMapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
+	if err != nil {
+		return "", err
+	}
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = k.NamespaceOrDefault(namespace)
+	} else {
+		namespace = ""
+	}
+	cfg := rest.CopyConfig(k.manager.cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &accessControlRoundTripper{
+			ctx:          ctx,
+			delegate:     rt,
+			restMapper:   k.manager.accessControlRESTMapper.delegate,
+			staticConfig: k.manager.staticConfig,
 		}
-		parsedResources = append(parsedResources, &obj)
+	})
+	describer, ok := describe.DescriberFor(mapping.GroupVersionKind.GroupKind(), cfg)
+	if !ok {
+		if describer, ok = describe.GenericDescriberFor(mapping, cfg); !ok {
+			return "", fmt.Errorf("no describer available for %s", mapping.GroupVersionKind.String())
+		}
+	}
+	return describer.Describe(namespace, name, describe.DescriberSettings{ShowEvents: true, ChunkSize: resourcesDescribeChunkSize})
+}
+
+func (k *Kubernetes) ResourcesCreateOrUpdate(ctx context.Context, resource string, options ResourcesCreateOrUpdateOptions) ([]*unstructured.Unstructured, error) {
+	parsedResources, err := parseResources(resource)
+	if err != nil {
+		return nil, err
+	}
+	return k.resourcesCreateOrUpdate(ctx, parsedResources, options)
+}
+
+// ResourcesDiff applies the provided resources with server-side dry-run and returns a unified diff between the live objects
+// and the dry-run results for every document of the provided YAML or JSON (empty if nothing would change).
+func (k *Kubernetes) ResourcesDiff(ctx context.Context, resource string) (string, error) {
+	parsedResources, err := parseResources(resource)
+	if err != nil {
+		return "", err
+	}
+	live := make([]*unstructured.Unstructured, len(parsedResources))
+	for i, obj := range parsedResources {
+		gvk := obj.GroupVersionKind()
+		live[i], err = k.ResourcesGet(ctx, &gvk, obj.GetNamespace(), obj.GetName())
+		if apierrors.IsNotFound(err) {
+			live[i] = nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+	merged, err := k.resourcesCreateOrUpdate(ctx, parsedResources, ResourcesCreateOrUpdateOptions{DryRun: true})
+	if err != nil {
+		return "", err
 	}
-	return k.resourcesCreateOrUpdate(ctx, parsedResources)
+	diff := strings.Builder{}
+	for i := range merged {
+		resourceDiff, diffErr := resourcesDiff(live[i], merged[i])
+		if diffErr != nil {
+			return "", diffErr
+		}
+		diff.WriteString(resourceDiff)
+	}
+	return diff.String(), nil
 }
 
-func (k *Kubernetes) ResourcesDelete(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) error {
+// ResourcesPatch applies the provided JSON, merge or strategic merge patch (JSON or YAML) to the resource,
+// or to its status or scale subresource if provided.
+func (k *Kubernetes) ResourcesPatch(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, patchType types.PatchType, patch string, subresource string) (*unstructured.Unstructured, error) {
 	gvr, err := k.resourceFor(gvk)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	data, err := yaml.ToJSON([]byte(patch))
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
 	}
 
 	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
 	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
 		namespace = k.NamespaceOrDefault(namespace)
 	}
-	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	var subresources []string
+	if subresource != "" {
+		subresources = append(subresources, subresource)
+	}
+	return k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{
+		FieldManager: version.BinaryName,
+	}, subresources...)
+}
+
+// ResourcesDelete deletes the resource with the provided name, or every resource matching the label selector (DeleteCollection).
+// If options.Wait is set, it blocks until the deleted resources are removed from the cluster (i.e. their finalizers completed)
+// or the wait timeout expires, the resources that are still present are returned in ResourcesDeleteResult.Pending.
+// The deleted resources are tracked by UID, so waiting for a named resource also requires the get verb.
+func (k *Kubernetes) ResourcesDelete(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourcesDeleteOptions) (*ResourcesDeleteResult, error) {
+	if (options.Name == "") == (options.LabelSelector == "") {
+		return nil, errors.New("either a name or a label selector is required")
+	}
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	resource := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace)
+	deleteOptions := metav1.DeleteOptions{
+		PropagationPolicy:  options.PropagationPolicy,
+		GracePeriodSeconds: options.GracePeriodSeconds,
+	}
+	result := &ResourcesDeleteResult{}
+	// UIDs of the deleted resources, resources recreated with the same name (e.g. by a controller) are not waited for
+	deletedUIDs := map[string]types.UID{}
+	if options.Name != "" {
+		if options.Wait {
+			// The UID is only required (get verb) to wait for the removal of this specific resource
+			obj, err := resource.Get(ctx, options.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			deleteOptions.Preconditions = metav1.NewUIDPreconditions(string(obj.GetUID()))
+			deletedUIDs[obj.GetName()] = obj.GetUID()
+		}
+		if err = resource.Delete(ctx, options.Name, deleteOptions); err != nil {
+			return nil, err
+		}
+		result.Deleted = append(result.Deleted, options.Name)
+	} else {
+		list, err := resource.List(ctx, metav1.ListOptions{LabelSelector: options.LabelSelector})
+		if err != nil {
+			return nil, err
+		}
+		if len(list.Items) == 0 {
+			return result, nil
+		}
+		// DeleteCollection lists the resources at the same resource version, so that exactly the listed resources are deleted
+		err = resource.DeleteCollection(ctx, deleteOptions, metav1.ListOptions{
+			LabelSelector:        options.LabelSelector,
+			ResourceVersion:      list.GetResourceVersion(),
+			ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+		})
+		if apierrors.IsMethodNotSupported(err) {
+			// Some kinds (e.g. Namespaces) don't support deleting collections, the listed resources are deleted one by one instead
+			for _, item := range list.Items {
+				itemDeleteOptions := deleteOptions
+				itemDeleteOptions.Preconditions = metav1.NewUIDPreconditions(string(item.GetUID()))
+				err = resource.Delete(ctx, item.GetName(), itemDeleteOptions)
+				if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+					// Already deleted, or replaced by a new resource with the same name, after being listed
+					continue
+				} else if err != nil {
+					return result, err
+				}
+				result.Deleted = append(result.Deleted, item.GetName())
+				deletedUIDs[item.GetName()] = item.GetUID()
+			}
+		} else if err != nil {
+			return nil, err
+		} else {
+			for _, item := range list.Items {
+				result.Deleted = append(result.Deleted, item.GetName())
+				deletedUIDs[item.GetName()] = item.GetUID()
+			}
+		}
+	}
+	if !options.Wait || len(deletedUIDs) == 0 {
+		return result, nil
+	}
+	waitResults, err := k.resourcesWait(ctx, gvk, namespace, ResourcesWaitOptions{
+		Name:          options.Name,
+		LabelSelector: options.LabelSelector,
+		For:           "delete",
+		Timeout:       options.WaitTimeout,
+	}, deletedUIDs)
+	if err != nil {
+		return result, err
+	}
+	for _, waitResult := range waitResults {
+		if !waitResult.Met {
+			result.Pending = append(result.Pending, waitResult.Object)
+		}
+	}
+	return result, nil
 }
 
 // resourcesListAsTable retrieves a list of resources in a table format.
@@ -132,7 +342,11 @@ func (k *Kubernetes) resourcesListAsTable(ctx context.Context, gvk *schema.Group
 	return &unstructured.Unstructured{Object: unstructuredObject}, err
 }
 
-func (k *Kubernetes) resourcesCreateOrUpdate(ctx context.Context, resources []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+func (k *Kubernetes) resourcesCreateOrUpdate(ctx context.Context, resources []*unstructured.Unstructured, options ResourcesCreateOrUpdateOptions) ([]*unstructured.Unstructured, error) {
+	applyOptions := metav1.ApplyOptions{FieldManager: version.BinaryName}
+	if options.DryRun {
+		applyOptions.DryRun = []string{metav1.DryRunAll}
+	}
 	for i, obj := range resources {
 		gvk := obj.GroupVersionKind()
 		gvr, rErr := k.resourceFor(&gvk)
@@ -145,20 +359,119 @@ func (k *Kubernetes) resourcesCreateOrUpdate(ctx context.Context, resources []*u
 		if namespaced, nsErr := k.isNamespaced(&gvk); nsErr == nil && namespaced {
 			namespace = k.NamespaceOrDefault(namespace)
 		}
-		resources[i], rErr = k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
-			FieldManager: version.BinaryName,
-		})
+		resources[i], rErr = k.manager.dynamicClient.Resource(*gvr).Namespace(namespace).Apply(ctx, obj.GetName(), obj, applyOptions)
 		if rErr != nil {
 			return nil, rErr
 		}
 		// Clear the cache to ensure the next operation is performed on the latest exposed APIs (will change after the CRD creation)
-		if gvk.Kind == "CustomResourceDefinition" {
+		if gvk.Kind == "CustomResourceDefinition" && !options.DryRun {
 			k.manager.accessControlRESTMapper.Reset()
 		}
 	}
 	return resources, nil
 }
 
+func parseResources(resource string) ([]*unstructured.Unstructured, error) {
+	separator := regexp.MustCompile(`\r?\n---\r?\n`)
+	resources := separator.Split(resource, -1)
+	var parsedResources []*unstructured.Unstructured
+	for _, r := range resources {
+		var obj unstructured.Unstructured
+		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(r)).Decode(&obj); err != nil {
+			return nil, err
+		}
+		parsedResources = append(parsedResources, &obj)
+	}
+	return parsedResources, nil
+}
+
+// resourcesDiff returns the unified diff between the YAML representations of the live and merged objects (managedFields are ignored).
+// A nil live object means the resource doesn't exist yet.
+// Same as kubectl diff, the values of Secrets are masked (changed values are still visible as a before/after mask).
+func resourcesDiff(live, merged *unstructured.Unstructured) (string, error) {
+	if merged.GetAPIVersion() == "v1" && merged.GetKind() == "Secret" {
+		var err error
+		if live, merged, err = resourcesDiffMaskSecret(live, merged); err != nil {
+			return "", err
+		}
+	}
+	toYaml := func(obj *unstructured.Unstructured) ([]string, error) {
+		if obj == nil {
+			return nil, nil
+		}
+		obj = obj.DeepCopy()
+		obj.SetManagedFields(nil)
+		data, err := sigsyaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		return strings.SplitAfter(strings.TrimSuffix(string(data), "\n"), "\n"), nil
+	}
+	liveLines, err := toYaml(live)
+	if err != nil {
+		return "", err
+	}
+	mergedLines, err := toYaml(merged)
+	if err != nil {
+		return "", err
+	}
+	id := merged.GetAPIVersion() + "/" + merged.GetKind()
+	if merged.GetNamespace() != "" {
+		id += "/" + merged.GetNamespace()
+	}
+	id += "/" + merged.GetName()
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        liveLines,
+		B:        mergedLines,
+		FromFile: "live/" + id,
+		ToFile:   "dry-run/" + id,
+		Context:  3,
+	})
+}
+
+// resourcesDiffMaskSecret masks the data of the live and merged Secrets with kubectl's diff.Masker.
+// The stringData values are merged into data first (same as the API server does) so that they are masked too.
+func resourcesDiffMaskSecret(live, merged *unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	var from runtime.Object
+	if live != nil {
+		live = live.DeepCopy()
+		if err := resourcesSecretMergeStringData(live); err != nil {
+			return nil, nil, err
+		}
+		from = live
+	}
+	merged = merged.DeepCopy()
+	if err := resourcesSecretMergeStringData(merged); err != nil {
+		return nil, nil, err
+	}
+	masker, err := diff.NewMasker(from, merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	maskedLive, _ := masker.From().(*unstructured.Unstructured)
+	maskedMerged, _ := masker.To().(*unstructured.Unstructured)
+	return maskedLive, maskedMerged, nil
+}
+
+func resourcesSecretMergeStringData(secret *unstructured.Unstructured) error {
+	stringData, found, err := unstructured.NestedStringMap(secret.Object, "stringData")
+	if err != nil || !found {
+		return err
+	}
+	data, _, err := unstructured.NestedMap(secret.Object, "data")
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	for key, value := range stringData {
+		data[key] = base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	unstructured.RemoveNestedField(secret.Object, "stringData")
+	return unstructured.SetNestedMap(secret.Object, data, "data")
+}
+
 func (k *Kubernetes) resourceFor(gvk *schema.GroupVersionKind) (*schema.GroupVersionResource, error) {
 	m, err := k.manager.accessControlRESTMapper.RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
 	if err != nil {
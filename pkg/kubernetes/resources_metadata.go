@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+type ResourcesMetadataOptions struct {
+	// Name of the resource to update
+	Name string
+	// LabelSelector to match the resources to update (alternative to Name)
+	LabelSelector string
+	// Set are the keys to add or update with their values
+	Set map[string]string
+	// Remove are the keys to remove (keys that are not present are ignored)
+	Remove []string
+	// Overwrite allows updating keys that already have a different value (same as kubectl --overwrite)
+	Overwrite bool
+}
+
+// ResourcesLabel adds, updates or removes the labels of the resource with the provided name, or of every resource matching
+// the label selector (same as kubectl label), and returns the updated resources
+func (k *Kubernetes) ResourcesLabel(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourcesMetadataOptions) ([]*unstructured.Unstructured, error) {
+	for key, value := range options.Set {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label value %s=%s: %s", key, value, strings.Join(errs, "; "))
+		}
+	}
+	return k.resourcesUpdateMetadata(ctx, gvk, namespace, "labels", options)
+}
+
+// ResourcesAnnotate adds, updates or removes the annotations of the resource with the provided name, or of every resource matching
+// the label selector (same as kubectl annotate), and returns the updated resources
+func (k *Kubernetes) ResourcesAnnotate(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourcesMetadataOptions) ([]*unstructured.Unstructured, error) {
+	return k.resourcesUpdateMetadata(ctx, gvk, namespace, "annotations", options)
+}
+
+// resourcesUpdateMetadata updates the metadata field (labels or annotations) of the matching resources with a merge patch,
+// so that the rest of the keys and fields are left untouched.
+// Unless overwrite is enabled, no resource is updated if any of them already has a different value for one of the keys to set.
+func (k *Kubernetes) resourcesUpdateMetadata(ctx context.Context, gvk *schema.GroupVersionKind, namespace, field string, options ResourcesMetadataOptions) ([]*unstructured.Unstructured, error) {
+	if (options.Name == "") == (options.LabelSelector == "") {
+		return nil, errors.New("either a name or a label selector is required")
+	}
+	if len(options.Set) == 0 && len(options.Remove) == 0 {
+		return nil, fmt.Errorf("at least one of the %s to set or remove is required", field)
+	}
+	patchValues := map[string]interface{}{}
+	for key, value := range options.Set {
+		patchValues[key] = value
+	}
+	for _, key := range append(sortedKeys(options.Set), options.Remove...) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid key %s: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	for _, key := range options.Remove {
+		if _, found := options.Set[key]; found {
+			return nil, fmt.Errorf("can not both modify and remove the key %s in the same command", key)
+		}
+		// A null value removes the key in a merge patch
+		patchValues[key] = nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{field: patchValues}})
+	if err != nil {
+		return nil, err
+	}
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	resource := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace)
+	var targets []unstructured.Unstructured
+	if options.Name != "" {
+		obj, err := resource.Get(ctx, options.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, *obj)
+	} else {
+		list, err := resource.List(ctx, metav1.ListOptions{LabelSelector: options.LabelSelector})
+		if err != nil {
+			return nil, err
+		}
+		if len(list.Items) == 0 {
+			return nil, fmt.Errorf("no resources found matching label selector %s", options.LabelSelector)
+		}
+		targets = list.Items
+	}
+	if !options.Overwrite {
+		for _, target := range targets {
+			current, _, _ := unstructured.NestedStringMap(target.Object, "metadata", field)
+			for _, key := range sortedKeys(options.Set) {
+				if value, found := current[key]; found && value != options.Set[key] {
+					return nil, fmt.Errorf("%s/%s already has a value (%s) for the key %s, and overwrite is false", gvk.Kind, target.GetName(), value, key)
+				}
+			}
+		}
+	}
+	updated := make([]*unstructured.Unstructured, 0, len(targets))
+	for _, target := range targets {
+		obj, err := resource.Patch(ctx, target.GetName(), types.MergePatchType, patch, metav1.PatchOptions{
+			FieldManager: version.BinaryName,
+		})
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, obj)
+	}
+	return updated, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,74 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resourcesListMultiConcurrency is the maximum number of kinds listed in parallel
+const resourcesListMultiConcurrency = 10
+
+// ResourcesListMultiResult is the outcome of listing one of the kinds requested in ResourcesListMulti
+type ResourcesListMultiResult struct {
+	GroupVersionKind schema.GroupVersionKind
+	List             runtime.Unstructured
+	Err              error
+}
+
+// ResourcesForCategory returns the listable kinds that belong to the provided category (e.g. all) according to the discovery categories.
+// Kinds that are not allowed by the access control configuration are excluded.
+func (k *Kubernetes) ResourcesForCategory(category string) ([]schema.GroupVersionKind, error) {
+	gvks, err := k.listableKinds(func(apiResource metav1.APIResource) bool {
+		return slices.Contains(apiResource.Categories, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(gvks) == 0 {
+		return nil, fmt.Errorf("no resources found for category %s", category)
+	}
+	return gvks, nil
+}
+
+// listableKinds returns the kinds (preferred versions) that support the list verb, are allowed by the access control configuration,
+// and match the provided filter
+func (k *Kubernetes) listableKinds(filter func(apiResource metav1.APIResource) bool) ([]schema.GroupVersionKind, error) {
+	apiResourceLists, err := k.preferredAllowedAPIResources()
+	if err != nil {
+		return nil, err
+	}
+	var gvks []schema.GroupVersionKind
+	for _, apiResourceList := range apiResourceLists {
+		gv, _ := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		for _, apiResource := range apiResourceList.APIResources {
+			if !slices.Contains(apiResource.Verbs, "list") || !filter(apiResource) {
+				continue
+			}
+			gvks = append(gvks, gv.WithKind(apiResource.Kind))
+		}
+	}
+	return gvks, nil
+}
+
+// ResourcesListMulti lists the provided kinds in parallel and returns a result for each of them, in the same order.
+// Failing to list a kind doesn't prevent the rest from being listed, the error is reported in its result.
+func (k *Kubernetes) ResourcesListMulti(ctx context.Context, gvks []schema.GroupVersionKind, namespace string, options ResourceListOptions) []ResourcesListMultiResult {
+	results := make([]ResourcesListMultiResult, len(gvks))
+	tasks, tasksCtx := errgroup.WithContext(ctx)
+	tasks.SetLimit(resourcesListMultiConcurrency)
+	for i, gvk := range gvks {
+		tasks.Go(func() error {
+			results[i].GroupVersionKind = gvk
+			results[i].List, results[i].Err = k.ResourcesList(tasksCtx, &gvk, namespace, options)
+			return nil
+		})
+	}
+	_ = tasks.Wait()
+	return results
+}
@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ResourcesTreeNode is a resource in the owner reference tree returned by ResourcesTree
+type ResourcesTreeNode struct {
+	Object *unstructured.Unstructured
+	// Ready is the readiness status of the resource (True, False, Unknown), empty if the resource doesn't report it
+	Ready string
+	// Reason for the readiness status (if any)
+	Reason   string
+	Children []*ResourcesTreeNode
+}
+
+// ResourcesTree is the owner reference tree of a resource
+type ResourcesTree struct {
+	Root *ResourcesTreeNode
+	// ListErrors are the kinds that couldn't be listed when looking for descendants (the tree may be incomplete)
+	ListErrors []ResourcesListMultiResult
+}
+
+// ResourcesTree returns the tree of resources that are owned, directly or transitively, by the provided resource (same as kubectl tree).
+// The descendants are searched for in all the namespaced kinds available in the cluster, in the namespace of the resource
+// (or in all namespaces for cluster-scoped resources).
+func (k *Kubernetes) ResourcesTree(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*ResourcesTree, error) {
+	root, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	gvks, err := k.listableKinds(func(apiResource metav1.APIResource) bool {
+		// Events never have owners and are usually the largest collections
+		return apiResource.Namespaced && apiResource.Kind != "Event"
+	})
+	if err != nil {
+		return nil, err
+	}
+	tree := &ResourcesTree{}
+	children := map[types.UID][]*unstructured.Unstructured{}
+	for _, result := range k.ResourcesListMulti(ctx, gvks, root.GetNamespace(), ResourceListOptions{}) {
+		if result.Err != nil {
+			tree.ListErrors = append(tree.ListErrors, result)
+			continue
+		}
+		items, err := meta.ExtractList(result.List)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			obj.SetAPIVersion(result.GroupVersionKind.GroupVersion().String())
+			obj.SetKind(result.GroupVersionKind.Kind)
+			for _, ownerReference := range obj.GetOwnerReferences() {
+				children[ownerReference.UID] = append(children[ownerReference.UID], obj)
+			}
+		}
+	}
+	tree.Root = resourcesTreeNode(root, children, map[types.UID]bool{})
+	return tree, nil
+}
+
+// resourcesTreeNode builds the tree node of the object and its descendants, sorted by kind and name
+func resourcesTreeNode(obj *unstructured.Unstructured, children map[types.UID][]*unstructured.Unstructured, visited map[types.UID]bool) *ResourcesTreeNode {
+	visited[obj.GetUID()] = true
+	node := &ResourcesTreeNode{Object: obj}
+	node.Ready, node.Reason = resourceReadiness(obj)
+	owned := children[obj.GetUID()]
+	sort.Slice(owned, func(i, j int) bool {
+		if owned[i].GetKind() != owned[j].GetKind() {
+			return owned[i].GetKind() < owned[j].GetKind()
+		}
+		return owned[i].GetName() < owned[j].GetName()
+	})
+	for _, child := range owned {
+		// Guard against ownership cycles
+		if visited[child.GetUID()] {
+			continue
+		}
+		node.Children = append(node.Children, resourcesTreeNode(child, children, visited))
+	}
+	return node
+}
+
+// resourceReadiness returns the readiness status and reason of the object based on its Ready condition,
+// or the most meaningful alternative for kinds that don't report it (e.g. Available for Deployments, Complete for Jobs,
+// or the number of ready replicas for ReplicaSets)
+func resourceReadiness(obj *unstructured.Unstructured) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, conditionType := range []string{"Ready", "Available", "Complete", "Failed"} {
+		for _, rawCondition := range conditions {
+			condition, ok := rawCondition.(map[string]interface{})
+			if !ok || condition["type"] != conditionType {
+				continue
+			}
+			status, _ := condition["status"].(string)
+			reason, _ := condition["reason"].(string)
+			if conditionType == "Failed" && strings.EqualFold(status, "True") {
+				return "False", reason
+			} else if conditionType == "Failed" {
+				continue
+			}
+			return status, reason
+		}
+	}
+	if replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas"); found {
+		readyReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		if readyReplicas >= replicas {
+			return "True", ""
+		}
+		return "False", fmt.Sprintf("%d/%d replicas ready", readyReplicas, replicas)
+	}
+	return "", ""
+}
@@ -0,0 +1,273 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// DefaultResourcesWaitTimeout is the maximum time to wait for a condition when no timeout is provided
+const DefaultResourcesWaitTimeout = 30 * time.Second
+
+type ResourcesWaitOptions struct {
+	// Name of the resource to wait for
+	Name string
+	// LabelSelector to match the resources to wait for (alternative to Name)
+	LabelSelector string
+	// For is the condition to wait for (same as kubectl wait --for), one of:
+	// 'delete', 'condition=Available', 'condition=Ready=False', or 'jsonpath={.status.phase}=Running'
+	For string
+	// Timeout is the maximum time to wait for the condition to be met by all the resources
+	Timeout time.Duration
+}
+
+// ResourcesWaitResult is the final state of one of the resources waited for in ResourcesWait
+type ResourcesWaitResult struct {
+	Name string
+	// Object is the last observed state of the resource (nil if it was deleted)
+	Object *unstructured.Unstructured
+	// Met is true if the resource met the condition before the timeout expired
+	Met bool
+	// uid of the resource waited for, a resource with the same name and a different UID is a new one (the original was deleted)
+	uid types.UID
+}
+
+// resourcesWaitCondition checks whether the provided object (nil if deleted) meets the condition to wait for
+type resourcesWaitCondition func(obj *unstructured.Unstructured) (bool, error)
+
+// ResourcesWait blocks until the resource with the provided name, or every resource matching the label selector, meets the condition
+// or the timeout expires, and returns the final state of each of them, sorted by name.
+// The resources are watched through the dynamic client, expiring the timeout is not an error (check ResourcesWaitResult.Met).
+func (k *Kubernetes) ResourcesWait(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourcesWaitOptions) ([]ResourcesWaitResult, error) {
+	return k.resourcesWait(ctx, gvk, namespace, options, nil)
+}
+
+// resourcesWait implements ResourcesWait, if uids (name to UID) is provided only these resources are waited for
+// and the ones that are not found with the same UID are considered deleted (e.g. the resources deleted by ResourcesDelete)
+func (k *Kubernetes) resourcesWait(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options ResourcesWaitOptions, uids map[string]types.UID) ([]ResourcesWaitResult, error) {
+	condition, err := parseResourcesWaitFor(options.For)
+	if err != nil {
+		return nil, err
+	}
+	if (options.Name == "") == (options.LabelSelector == "") {
+		return nil, errors.New("either a name or a label selector is required")
+	}
+	gvr, err := k.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := k.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = k.NamespaceOrDefault(namespace)
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultResourcesWaitTimeout
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	resource := k.manager.dynamicClient.Resource(*gvr).Namespace(namespace)
+	listOptions := metav1.ListOptions{LabelSelector: options.LabelSelector}
+	if options.Name != "" {
+		listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", options.Name).String()
+	}
+	list, err := resource.List(waitCtx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	results := map[string]*ResourcesWaitResult{}
+	for name, uid := range uids {
+		results[name] = &ResourcesWaitResult{Name: name, uid: uid}
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		if result, found := results[item.GetName()]; found && result.uid == item.GetUID() {
+			result.Object = item
+		} else if uids == nil {
+			results[item.GetName()] = &ResourcesWaitResult{Name: item.GetName(), Object: item, uid: item.GetUID()}
+		}
+	}
+	if len(results) == 0 {
+		if options.For != "delete" && options.Name != "" {
+			return nil, apierrors.NewNotFound(gvr.GroupResource(), options.Name)
+		} else if options.For != "delete" {
+			return nil, fmt.Errorf("no resources found matching label selector %s", options.LabelSelector)
+		}
+		return nil, nil
+	}
+	for _, result := range results {
+		if result.Met, err = condition(result.Object); err != nil {
+			return nil, err
+		}
+	}
+	listOptions.ResourceVersion = list.GetResourceVersion()
+	for !resourcesWaitMet(results) {
+		if err = resourcesWatch(waitCtx, resource, &listOptions, condition, results); err != nil {
+			return nil, err
+		}
+		if waitCtx.Err() != nil {
+			break
+		}
+	}
+	ret := make([]ResourcesWaitResult, 0, len(results))
+	for _, result := range results {
+		ret = append(ret, *result)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret, nil
+}
+
+// resourcesWatch updates the results with the events of the watched resources until all of them meet the condition,
+// the watch is closed by the server (it should be restarted from the updated resource version), or the context is done
+func resourcesWatch(ctx context.Context, resource dynamic.ResourceInterface, listOptions *metav1.ListOptions, condition resourcesWaitCondition, results map[string]*ResourcesWaitResult) error {
+	watcher, err := resource.Watch(ctx, *listOptions)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			if event.Type == watch.Error {
+				return apierrors.FromObject(event.Object)
+			}
+			obj, isUnstructured := event.Object.(*unstructured.Unstructured)
+			if !isUnstructured {
+				continue
+			}
+			listOptions.ResourceVersion = obj.GetResourceVersion()
+			result, found := results[obj.GetName()]
+			if !found {
+				// Resources created after the wait started (label selector) are ignored
+				continue
+			}
+			result.Object = obj
+			// Same as kubectl wait, a new resource with the same name means that the one waited for was deleted
+			if event.Type == watch.Deleted || obj.GetUID() != result.uid {
+				result.Object = nil
+			}
+			if result.Met, err = condition(result.Object); err != nil {
+				return err
+			}
+			if resourcesWaitMet(results) {
+				return nil
+			}
+		}
+	}
+}
+
+func resourcesWaitMet(results map[string]*ResourcesWaitResult) bool {
+	for _, result := range results {
+		if !result.Met {
+			return false
+		}
+	}
+	return true
+}
+
+// parseResourcesWaitFor parses the condition to wait for (same as kubectl wait --for)
+func parseResourcesWaitFor(waitFor string) (resourcesWaitCondition, error) {
+	switch {
+	case strings.EqualFold(waitFor, "delete"):
+		return func(obj *unstructured.Unstructured) (bool, error) {
+			return obj == nil, nil
+		}, nil
+	case strings.HasPrefix(strings.ToLower(waitFor), "condition="):
+		conditionType, conditionStatus, _ := strings.Cut(waitFor[len("condition="):], "=")
+		if conditionType == "" {
+			return nil, fmt.Errorf("invalid condition %s, expected condition=<type>[=<status>]", waitFor)
+		}
+		if conditionStatus == "" {
+			conditionStatus = "True"
+		}
+		return func(obj *unstructured.Unstructured) (bool, error) {
+			if obj == nil {
+				return false, fmt.Errorf("resource was deleted while waiting for %s", waitFor)
+			}
+			return resourcesConditionMet(obj, conditionType, conditionStatus), nil
+		}, nil
+	case strings.HasPrefix(strings.ToLower(waitFor), "jsonpath="):
+		template, value, err := parseResourcesWaitJsonPath(waitFor[len("jsonpath="):])
+		if err != nil {
+			return nil, err
+		}
+		jp := jsonpath.New("wait").AllowMissingKeys(true)
+		if err = jp.Parse(template); err != nil {
+			return nil, fmt.Errorf("invalid jsonpath %s: %w", template, err)
+		}
+		return func(obj *unstructured.Unstructured) (bool, error) {
+			if obj == nil {
+				return false, fmt.Errorf("resource was deleted while waiting for %s", waitFor)
+			}
+			return resourcesJsonPathMet(jp, obj, value)
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid wait condition %s, expected one of: delete, condition=<type>[=<status>], jsonpath={<path>}=<value>", waitFor)
+}
+
+// parseResourcesWaitJsonPath splits a '{.status.phase}=Running' expression into the JSONPath template and the expected value
+func parseResourcesWaitJsonPath(expression string) (string, string, error) {
+	template, value := expression, ""
+	if strings.HasPrefix(expression, "{") {
+		if i := strings.LastIndex(expression, "}="); i >= 0 {
+			template, value = expression[:i+1], expression[i+2:]
+		}
+	} else {
+		template, value, _ = strings.Cut(expression, "=")
+		template = "{" + template + "}"
+	}
+	if value == "" {
+		return "", "", fmt.Errorf("invalid jsonpath condition %s, expected jsonpath={<path>}=<value>", expression)
+	}
+	return template, value, nil
+}
+
+// resourcesConditionMet checks whether the object has a status condition of the provided type and status (case-insensitive)
+// that is up to date with the latest generation of the object
+func resourcesConditionMet(obj *unstructured.Unstructured, conditionType, conditionStatus string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, rawCondition := range conditions {
+		condition, ok := rawCondition.(map[string]interface{})
+		if !ok || !strings.EqualFold(fmt.Sprint(condition["type"]), conditionType) {
+			continue
+		}
+		if observedGeneration, found, _ := unstructured.NestedInt64(condition, "observedGeneration"); found && observedGeneration < obj.GetGeneration() {
+			return false
+		}
+		return strings.EqualFold(fmt.Sprint(condition["status"]), conditionStatus)
+	}
+	return false
+}
+
+// resourcesJsonPathMet checks whether the JSONPath evaluates to a single value equal to the provided one
+func resourcesJsonPathMet(jp *jsonpath.JSONPath, obj *unstructured.Unstructured, value string) (bool, error) {
+	results, err := jp.FindResults(obj.Object)
+	if err != nil {
+		return false, err
+	}
+	if len(results) != 1 || len(results[0]) != 1 {
+		return false, nil
+	}
+	return fmt.Sprint(results[0][0].Interface()) == value, nil
+}
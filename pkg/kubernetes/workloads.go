@@ -0,0 +1,359 @@
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+)
+
+// WorkloadKinds are the supported kinds of workloads that manage Pods through a label selector
+var WorkloadKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+}
+
+// RolloutKinds are the supported kinds of workloads that roll out Pod template changes and keep a revision history
+// (ReplicaSets for Deployments, ControllerRevisions for StatefulSets and DaemonSets)
+var RolloutKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+}
+
+// workloadLogsConcurrency is the maximum number of container logs retrieved in parallel
+const workloadLogsConcurrency = 10
+
+// rolloutRestartedAtAnnotation is the Pod template annotation updated to trigger a rollout restart (same as kubectl rollout restart)
+const rolloutRestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+type WorkloadLogsOptions struct {
+	PodsLogOptions
+	// Kind and Name of the workload whose Pods logs should be retrieved
+	Kind string
+	Name string
+	// LabelSelector to match the Pods whose logs should be retrieved (alternative to Kind and Name)
+	LabelSelector string
+}
+
+type workloadLogLine struct {
+	timestamp time.Time
+	prefix    string
+	message   string
+}
+
+// WorkloadsLog retrieves the logs of every container of the Pods matching the provided workload or label selector.
+// The log lines are interleaved by timestamp and prefixed with the pod/container they belong to.
+func (k *Kubernetes) WorkloadsLog(ctx context.Context, namespace string, options WorkloadLogsOptions) (string, error) {
+	namespace = k.NamespaceOrDefault(namespace)
+	labelSelector := options.LabelSelector
+	if options.Kind != "" || options.Name != "" {
+		var err error
+		if labelSelector, err = k.workloadSelector(ctx, namespace, options.Kind, options.Name); err != nil {
+			return "", err
+		}
+	}
+	if labelSelector == "" {
+		return "", fmt.Errorf("either a workload kind and name or a label selector is required")
+	}
+	pods, err := k.podsForSelector(ctx, namespace, labelSelector)
+	if err != nil {
+		return "", err
+	}
+	if len(pods) == 0 {
+		return fmt.Sprintf("No pods found matching label selector %s in namespace %s", labelSelector, namespace), nil
+	}
+	var (
+		mutex    sync.Mutex
+		lines    []workloadLogLine
+		failures []string
+	)
+	tasks, tasksCtx := errgroup.WithContext(ctx)
+	tasks.SetLimit(workloadLogsConcurrency)
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if options.Container != "" && options.Container != container.Name {
+				continue
+			}
+			podName, containerName := pod.Name, container.Name
+			tasks.Go(func() error {
+				logOptions := options.PodsLogOptions
+				logOptions.Container = containerName
+				// Timestamps are always requested so that lines from different containers can be interleaved
+				logOptions.Timestamps = true
+				ret, err := k.PodsLog(tasksCtx, namespace, podName, logOptions)
+				mutex.Lock()
+				defer mutex.Unlock()
+				if err != nil {
+					failures = append(failures, fmt.Sprintf("%s/%s: %v", podName, containerName, err))
+					return nil
+				}
+				lines = append(lines, parseWorkloadLogLines(podName+"/"+containerName, ret)...)
+				return nil
+			})
+		}
+	}
+	_ = tasks.Wait()
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp.Before(lines[j].timestamp)
+	})
+	sort.Strings(failures)
+	var ret strings.Builder
+	for _, failure := range failures {
+		ret.WriteString("# failed to get logs for " + failure + "\n")
+	}
+	for _, line := range lines {
+		ret.WriteString("[" + line.prefix + "] ")
+		if options.Timestamps && !line.timestamp.IsZero() {
+			ret.WriteString(line.timestamp.Format(time.RFC3339Nano) + " ")
+		}
+		ret.WriteString(line.message + "\n")
+	}
+	return ret.String(), nil
+}
+
+// WorkloadsScale sets the number of replicas of the workload through its scale subresource and returns the resulting Scale
+func (k *Kubernetes) WorkloadsScale(ctx context.Context, namespace, kind, name string, replicas int32) (*unstructured.Unstructured, error) {
+	gvk, err := rolloutGroupVersionKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if gvk.Kind == "DaemonSet" {
+		return nil, fmt.Errorf("DaemonSet %s can't be scaled, it runs one Pod on each eligible Node", name)
+	}
+	return k.ResourcesPatch(ctx, gvk, namespace, name, types.MergePatchType, fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas), "scale")
+}
+
+// RolloutRestart triggers a rolling restart of the Pods of the workload by updating the restartedAt annotation of its Pod template
+func (k *Kubernetes) RolloutRestart(ctx context.Context, namespace, kind, name string) (*unstructured.Unstructured, error) {
+	gvk, err := rolloutGroupVersionKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	workload, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if paused, _, _ := unstructured.NestedBool(workload.Object, "spec", "paused"); paused {
+		return nil, fmt.Errorf("can't restart paused %s %s, resume its rollout first", gvk.Kind, name)
+	}
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, rolloutRestartedAtAnnotation, time.Now().Format(time.RFC3339))
+	return k.ResourcesPatch(ctx, gvk, namespace, name, types.StrategicMergePatchType, patch, "")
+}
+
+// RolloutStatus returns the rollout status message of the workload (same as kubectl rollout status) and whether the rollout is complete.
+// If a timeout is provided and the rollout isn't complete, the workload is watched until it completes or the timeout expires.
+func (k *Kubernetes) RolloutStatus(ctx context.Context, namespace, kind, name string, timeout time.Duration) (string, bool, error) {
+	mapping, err := k.rolloutMapping(kind, false)
+	if err != nil {
+		return "", false, err
+	}
+	statusViewer, err := polymorphichelpers.StatusViewerFn(mapping)
+	if err != nil {
+		return "", false, err
+	}
+	resource := k.manager.dynamicClient.Resource(mapping.Resource).Namespace(k.NamespaceOrDefault(namespace))
+	workload, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", false, err
+	}
+	status, done, err := statusViewer.Status(workload, 0)
+	if err != nil || done || timeout <= 0 {
+		return status, done, err
+	}
+	watchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	watcher, err := resource.Watch(watchCtx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+		ResourceVersion: workload.GetResourceVersion(),
+	})
+	if err != nil {
+		return "", false, err
+	}
+	defer watcher.Stop()
+	for {
+		select {
+		case <-watchCtx.Done():
+			return status, false, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return status, false, nil
+			}
+			switch event.Type {
+			case watch.Deleted:
+				return "", false, fmt.Errorf("%s %s was deleted", mapping.GroupVersionKind.Kind, name)
+			case watch.Error:
+				return "", false, apierrors.FromObject(event.Object)
+			}
+			u, isUnstructured := event.Object.(*unstructured.Unstructured)
+			if !isUnstructured {
+				continue
+			}
+			if status, done, err = statusViewer.Status(u, 0); err != nil || done {
+				return status, done, err
+			}
+		}
+	}
+}
+
+// RolloutHistory returns the revisions of the workload, or the Pod template of the provided revision (same as kubectl rollout history)
+func (k *Kubernetes) RolloutHistory(_ context.Context, namespace, kind, name string, revision int64) (string, error) {
+	mapping, err := k.rolloutMapping(kind, true)
+	if err != nil {
+		return "", err
+	}
+	historyViewer, err := polymorphichelpers.HistoryViewerFn(k.manager, mapping)
+	if err != nil {
+		return "", err
+	}
+	return historyViewer.ViewHistory(k.NamespaceOrDefault(namespace), name, revision)
+}
+
+// RolloutUndo rolls the workload back to the provided revision, or to the previous one if revision is 0 (same as kubectl rollout undo).
+// In dry-run mode the workload isn't modified, and the Pod template of the target revision is returned instead.
+func (k *Kubernetes) RolloutUndo(ctx context.Context, namespace, kind, name string, toRevision int64, dryRun bool) (string, error) {
+	mapping, err := k.rolloutMapping(kind, true)
+	if err != nil {
+		return "", err
+	}
+	rollbacker, err := polymorphichelpers.RollbackerFn(k.manager, mapping)
+	if err != nil {
+		return "", err
+	}
+	workload, err := k.manager.dynamicClient.Resource(mapping.Resource).Namespace(k.NamespaceOrDefault(namespace)).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	dryRunStrategy := cmdutil.DryRunNone
+	if dryRun {
+		dryRunStrategy = cmdutil.DryRunClient
+	}
+	return rollbacker.Rollback(workload, nil, toRevision, dryRunStrategy)
+}
+
+// rolloutMapping returns the REST mapping of the provided rollout kind if it's allowed by the access control configuration,
+// and if history is requested, only if the kind used to store its revisions is also allowed
+func (k *Kubernetes) rolloutMapping(kind string, history bool) (*meta.RESTMapping, error) {
+	gvk, err := rolloutGroupVersionKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if history {
+		historyGvk := &schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ControllerRevision"}
+		if gvk.Kind == "Deployment" {
+			historyGvk.Kind = "ReplicaSet"
+		}
+		if !isAllowed(k.manager.staticConfig, historyGvk) {
+			return nil, isNotAllowedError(historyGvk)
+		}
+	}
+	return k.manager.accessControlRESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// workloadSelector returns the label selector of the Pods managed by the provided workload
+func (k *Kubernetes) workloadSelector(ctx context.Context, namespace, kind, name string) (string, error) {
+	if kind == "" || name == "" {
+		return "", fmt.Errorf("both workload kind and name are required")
+	}
+	gvk, err := workloadGroupVersionKind(kind)
+	if err != nil {
+		return "", err
+	}
+	workload, err := k.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return "", err
+	}
+	rawSelector, found, err := unstructured.NestedMap(workload.Object, "spec", "selector")
+	if err != nil || !found {
+		return "", fmt.Errorf("%s %s has no selector", gvk.Kind, name)
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(rawSelector, labelSelector); err != nil {
+		return "", err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", err
+	}
+	return selector.String(), nil
+}
+
+// podsForSelector returns the Pods in the provided namespace matching the label selector
+func (k *Kubernetes) podsForSelector(ctx context.Context, namespace, labelSelector string) ([]v1.Pod, error) {
+	raw, err := k.PodsListInNamespace(ctx, namespace, ResourceListOptions{
+		ListOptions: metav1.ListOptions{LabelSelector: labelSelector},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pods []v1.Pod
+	for _, item := range raw.(*unstructured.UnstructuredList).Items {
+		pod := v1.Pod{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &pod); err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+func workloadGroupVersionKind(kind string) (*schema.GroupVersionKind, error) {
+	return supportedGroupVersionKind(WorkloadKinds, kind)
+}
+
+func rolloutGroupVersionKind(kind string) (*schema.GroupVersionKind, error) {
+	return supportedGroupVersionKind(RolloutKinds, kind)
+}
+
+func supportedGroupVersionKind(kinds []schema.GroupVersionKind, kind string) (*schema.GroupVersionKind, error) {
+	for _, gvk := range kinds {
+		if strings.EqualFold(gvk.Kind, kind) {
+			return &gvk, nil
+		}
+	}
+	var supported []string
+	for _, gvk := range kinds {
+		supported = append(supported, gvk.Kind)
+	}
+	return nil, fmt.Errorf("unsupported workload kind %s, supported kinds are: %s", kind, strings.Join(supported, ", "))
+}
+
+// parseWorkloadLogLines splits the timestamped log output of a container into lines.
+// Lines without a parseable timestamp inherit the timestamp of the previous line to preserve their relative order.
+func parseWorkloadLogLines(prefix, logs string) []workloadLogLine {
+	var lines []workloadLogLine
+	var last time.Time
+	scanner := bufio.NewScanner(strings.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := workloadLogLine{timestamp: last, prefix: prefix, message: scanner.Text()}
+		if rawTimestamp, message, found := strings.Cut(line.message, " "); found {
+			if timestamp, err := time.Parse(time.RFC3339Nano, rawTimestamp); err == nil {
+				line.timestamp = timestamp
+				line.message = message
+				last = timestamp
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func apiResourcesDiscoveryHandler() http.Handler {
+	return test.NewDiscoveryClientHandler(
+		&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", ShortNames: []string{"cm"}, Verbs: []string{"get", "list"}},
+			{Name: "nodes", Kind: "Node", ShortNames: []string{"no"}, Verbs: []string{"get", "list"}},
+			{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: []string{"get", "list"}},
+		}},
+		&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Namespaced: true, Kind: "Deployment", ShortNames: []string{"deploy"}, Verbs: []string{"get", "list"}},
+			{Name: "deployments/scale", Namespaced: true, Kind: "Scale", Verbs: []string{"get", "patch"}},
+		}},
+	)
+}
+
+func apiResourcesHandler(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	// Request Performed by OpenAPI V3 client (Get OpenAPI paths)
+	if req.URL.Path == "/openapi/v3" {
+		_, _ = w.Write([]byte(`{"paths":{"api/v1":{"serverRelativeURL":"/openapi/v3/api/v1?hash=1"}}}`))
+		return
+	}
+	// Request Performed by OpenAPI V3 client (Get OpenAPI schema)
+	if req.URL.Path == "/openapi/v3/api/v1" {
+		_, _ = w.Write([]byte(`{"openapi":"3.0.0","info":{"title":"Kubernetes","version":"v1.33.0"},` +
+			`"paths":{"/api/v1/namespaces/{namespace}/configmaps":{"get":{"x-kubernetes-group-version-kind":{"group":"","kind":"ConfigMap","version":"v1"}}}},` +
+			`"components":{"schemas":{"io.k8s.api.core.v1.ConfigMap":{"description":"ConfigMap holds configuration data for pods to consume.","type":"object",` +
+			`"properties":{"data":{"description":"Data contains the configuration data.","type":"object","additionalProperties":{"type":"string","default":""}},` +
+			`"immutable":{"description":"Immutable, if set to true, ensures that data stored in the ConfigMap cannot be updated.","type":"boolean"}},` +
+			`"x-kubernetes-group-version-kind":[{"group":"","kind":"ConfigMap","version":"v1"}]}}}}`))
+		return
+	}
+}
+
+func TestApiResources(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Secret"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(apiResourcesDiscoveryHandler())
+		mockServer.Handle(http.HandlerFunc(apiResourcesHandler))
+		t.Run("api_resources lists allowed resources", func(t *testing.T) {
+			toolResult, err := c.callTool("api_resources", map[string]interface{}{})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{
+				"NAME          SHORTNAMES   APIVERSION   NAMESPACED   KIND         VERBS\n",
+				"configmaps    cm           v1           true         ConfigMap    get,list\n",
+				"nodes         no           v1           false        Node         get,list\n",
+				"deployments   deploy       apps/v1      true         Deployment   get,list\n",
+			} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q, got %v", expected, text)
+				}
+			}
+			if strings.Contains(text, "secrets") {
+				t.Errorf("denied resources should not be listed, got %v", text)
+			}
+			if strings.Contains(text, "deployments/scale") {
+				t.Errorf("subresources should not be listed, got %v", text)
+			}
+		})
+		t.Run("api_resources with apiGroup lists resources of the group", func(t *testing.T) {
+			toolResult, err := c.callTool("api_resources", map[string]interface{}{"apiGroup": "core"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "configmaps") || strings.Contains(text, "deployments") {
+				t.Errorf("expected only core resources, got %v", text)
+			}
+		})
+		t.Run("api_resources with unknown apiGroup returns no resources", func(t *testing.T) {
+			toolResult, err := c.callTool("api_resources", map[string]interface{}{"apiGroup": "example.com"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "No API resources found" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestResourcesExplain(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Secret"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(apiResourcesDiscoveryHandler())
+		mockServer.Handle(http.HandlerFunc(apiResourcesHandler))
+		t.Run("resources_explain explains the kind", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_explain", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{"KIND:       ConfigMap\n", "VERSION:    v1\n", "ConfigMap holds configuration data", "data\t<map[string]string>", "immutable\t<boolean>"} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q, got %v", expected, text)
+				}
+			}
+		})
+		t.Run("resources_explain explains the field", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_explain", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "field": "immutable"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "FIELD: immutable <boolean>") || strings.Contains(text, "data\t") {
+				t.Errorf("unexpected field explanation, got %v", text)
+			}
+		})
+		t.Run("resources_explain with unknown field returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_explain", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "field": "spec"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("resources_explain with denied resource returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_explain", map[string]interface{}{"apiVersion": "v1", "kind": "Secret"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to explain resource: resource not allowed") {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
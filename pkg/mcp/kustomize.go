@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func (s *Server) initKustomize() []server.ServerTool {
+	// The kustomize tools read the filesystem of the server host, they're only exposed when a kustomize root directory is configured
+	if s.configuration.StaticConfig.KustomizeRoot == "" {
+		return nil
+	}
+	return []server.ServerTool{
+		{Tool: mcp.NewTool("kustomize_build",
+			mcp.WithDescription("Render the kustomization (kustomization.yaml) in a directory of the host running the MCP server and return the resulting Kubernetes manifests (same as kubectl kustomize)"),
+			mcp.WithString("path", mcp.Description("Path of the directory containing the kustomization file in the filesystem of the host running the MCP server, not the client "+
+				"(relative to the configured kustomize root directory, or absolute within it). "+
+				"Remote bases and resources (git or http URLs) are only fetched if allowed by the server configuration"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Kustomize: Build"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true), // Remote bases may be fetched from git or http URLs (kustomize_allow_remote)
+		), Handler: s.kustomizeBuild},
+		{Tool: mcp.NewTool("kustomize_apply",
+			mcp.WithDescription("Render the kustomization (kustomization.yaml) in a directory of the host running the MCP server and create or update the resulting resources in the current cluster (same as kubectl apply -k --server-side)"),
+			mcp.WithString("path", mcp.Description("Path of the directory containing the kustomization file in the filesystem of the host running the MCP server, not the client "+
+				"(relative to the configured kustomize root directory, or absolute within it). "+
+				"Remote bases and resources (git or http URLs) are only fetched if allowed by the server configuration"), mcp.Required()),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, submit the resources with server-side dry-run and return the result without persisting any change (Optional)")),
+			mcp.WithBoolean("diff", mcp.Description("If true, return a unified diff between the live resources and the result of applying the kustomization (server-side dry-run, nothing is persisted) (Optional)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Kustomize: Apply"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.kustomizeApply},
+	}
+}
+
+func (s *Server) kustomizeBuild(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path, _ := ctr.GetArguments()["path"].(string)
+	if path == "" {
+		return NewTextResult("", errors.New("failed to build kustomization, missing argument path")), nil
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := derived.KustomizeBuild(path)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to build kustomization in %s: %v", path, err)), nil
+	}
+	return NewTextResult(manifests, nil), nil
+}
+
+func (s *Server) kustomizeApply(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path, _ := ctr.GetArguments()["path"].(string)
+	if path == "" {
+		return NewTextResult("", errors.New("failed to apply kustomization, missing argument path")), nil
+	}
+	dryRun, _ := ctr.GetArguments()["dryRun"].(bool)
+	diff, _ := ctr.GetArguments()["diff"].(bool)
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if diff {
+		manifests, err := derived.KustomizeBuild(path)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to build kustomization in %s: %v", path, err)), nil
+		}
+		ret, err := derived.ResourcesDiff(ctx, manifests)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to diff kustomization resources: %v", err)), nil
+		}
+		if ret == "" {
+			return NewTextResult("# No changes would be applied to the resources (dry run)\n", nil), nil
+		}
+		return NewTextResult("# The following changes (unified diff) would be applied to the resources (dry run)\n"+ret, nil), nil
+	}
+	resources, err := derived.KustomizeApply(ctx, path, kubernetes.ResourcesCreateOrUpdateOptions{DryRun: dryRun})
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to apply kustomization in %s: %v", path, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(resources)
+	if err != nil {
+		err = fmt.Errorf("failed to apply kustomization in %s: %v", path, err)
+	}
+	if dryRun {
+		return NewTextResult("# The following resources (YAML) would be created or updated (dry run)\n"+marshalledYaml, err), nil
+	}
+	return NewTextResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+}
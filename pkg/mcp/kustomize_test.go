@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func kustomizeTestdata() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", "kustomize")
+}
+
+func TestKustomizeDisabled(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		tools, err := c.mcpClient.ListTools(c.ctx, mcp.ListToolsRequest{})
+		t.Run("ListTools without kustomize root doesn't expose the kustomize tools", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call ListTools failed %v", err)
+			}
+			for _, tool := range tools.Tools {
+				if strings.HasPrefix(tool.Name, "kustomize_") {
+					t.Errorf("tool %s should not be exposed", tool.Name)
+				}
+			}
+		})
+	})
+}
+
+func TestKustomize(t *testing.T) {
+	testCaseWithContext(t, &mcpContext{staticConfig: &config.StaticConfig{KustomizeRoot: kustomizeTestdata()}}, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var applied []string
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list", "patch"}},
+				{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get", "list", "patch"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if req.Method == http.MethodPatch {
+				applied = append(applied, req.URL.Path+"?"+req.URL.Query().Get("dryRun"))
+				body, _ := io.ReadAll(req.Body)
+				_, _ = w.Write(body)
+			}
+		}))
+		overlay := filepath.Join(kustomizeTestdata(), "overlay")
+		t.Run("kustomize_build with missing path returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("kustomize_build", map[string]interface{}{})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to build kustomization, missing argument path" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_build with invalid path returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("kustomize_build", map[string]interface{}{"path": filepath.Join(overlay, "missing")})
+			if !toolResult.IsError || !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to build kustomization in ") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_build returns the rendered manifests", func(t *testing.T) {
+			toolResult, err := c.callTool("kustomize_build", map[string]interface{}{"path": overlay})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: staging\n---\n" +
+				"apiVersion: v1\ndata:\n  log-level: debug\nkind: ConfigMap\nmetadata:\n  name: staging-app-config\n  namespace: staging\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Errorf("unexpected manifests, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_build with path relative to the kustomize root returns the rendered manifests", func(t *testing.T) {
+			toolResult, err := c.callTool("kustomize_build", map[string]interface{}{"path": "overlay"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "  name: staging-app-config\n") {
+				t.Errorf("unexpected manifests, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_build with path outside of the kustomize root returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("kustomize_build", map[string]interface{}{"path": ".."})
+			if !toolResult.IsError || !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, " is outside of the kustomize root directory "+kustomizeTestdata()) {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_build with base outside of the kustomize root returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("kustomize_build", map[string]interface{}{"path": "escape"})
+			if !toolResult.IsError || !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, " is outside of the kustomize root directory "+kustomizeTestdata()) {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_build with remote base returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("kustomize_build", map[string]interface{}{"path": "remote"})
+			if !toolResult.IsError || !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text,
+				"github.com/example/kustomizations//base?ref=v1.0.0 referenced in "+filepath.Join(kustomizeTestdata(), "remote")+
+					" is not a local file or directory, remote kustomize bases and resources are disabled") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_build with remote file returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("kustomize_build", map[string]interface{}{"path": "remote-patch"})
+			if !toolResult.IsError || !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text,
+				"remote file https://example.com/patches/log-level.yaml referenced in "+filepath.Join(kustomizeTestdata(), "remote-patch")+
+					" is not allowed, remote kustomize bases and resources are disabled") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_apply applies the rendered resources in order", func(t *testing.T) {
+			applied = nil
+			toolResult, err := c.callTool("kustomize_apply", map[string]interface{}{"path": overlay})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if strings.Join(applied, ",") != "/api/v1/namespaces/staging?,/api/v1/namespaces/staging/configmaps/staging-app-config?" {
+				t.Errorf("unexpected applied resources %v", applied)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The following resources (YAML) have been created or updated successfully\n") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("kustomize_apply with dryRun applies with server-side dry-run", func(t *testing.T) {
+			applied = nil
+			toolResult, err := c.callTool("kustomize_apply", map[string]interface{}{"path": overlay, "dryRun": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if strings.Join(applied, ",") != "/api/v1/namespaces/staging?All,/api/v1/namespaces/staging/configmaps/staging-app-config?All" {
+				t.Errorf("unexpected applied resources %v", applied)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The following resources (YAML) would be created or updated (dry run)\n") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
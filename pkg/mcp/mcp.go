@@ -51,6 +51,7 @@ type Server struct {
 	server        *server.MCPServer
 	enabledTools  []string
 	k             *internalk8s.Manager
+	portForwards  *internalk8s.PortForwardSessions
 }
 
 func NewServer(configuration Configuration) (*Server, error) {
@@ -73,6 +74,7 @@ func NewServer(configuration Configuration) (*Server, error) {
 			version.Version,
 			serverOptions...,
 		),
+		portForwards: internalk8s.NewPortForwardSessions(),
 	}
 	if err := s.reloadKubernetesClient(); err != nil {
 		return nil, err
@@ -147,6 +149,7 @@ func (s *Server) Close() {
 	if s.k != nil {
 		s.k.Close()
 	}
+	s.portForwards.Close()
 }
 
 func NewTextResult(content string, err error) *mcp.CallToolResult {
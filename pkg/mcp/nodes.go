@@ -0,0 +1,259 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/metrics"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// nodesDrainMaxTimeout is the maximum number of seconds nodes_drain can wait for the Pods to be evicted
+const nodesDrainMaxTimeout = 300
+
+// nodesDrainFlags replaces the kubectl flags suggested by the drain errors with the nodes_drain arguments
+var nodesDrainFlags = strings.NewReplacer("--ignore-daemonsets", "ignoreDaemonSets", "--delete-emptydir-data", "deleteEmptyDirData", "--force", "force")
+
+func (s *Server) initNodes() []server.ServerTool {
+	return []server.ServerTool{
+		{Tool: mcp.NewTool("nodes_top",
+			mcp.WithDescription("List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the Kubernetes Nodes in the cluster, "+
+				"along with the allocatable capacity of each Node and the percentage in use"),
+			mcp.WithString("name", mcp.Description("Name of the Node to get the resource consumption from (Optional, all Nodes if not provided)")),
+			mcp.WithString("label_selector", mcp.Description("Kubernetes label selector (e.g. 'node-role.kubernetes.io/worker=') to filter the Nodes by label (Optional, only applicable when name is not provided)"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Top"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesTop},
+		{Tool: mcp.NewTool("nodes_cordon",
+			mcp.WithDescription("Mark a Kubernetes Node as unschedulable (same as kubectl cordon), new Pods won't be scheduled to it while the existing ones keep running"),
+			mcp.WithString("name", mcp.Description("Name of the Node to cordon"), mcp.Required()),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, only check whether the Node would be changed (Optional, defaults to false)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Cordon"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesCordon},
+		{Tool: mcp.NewTool("nodes_uncordon",
+			mcp.WithDescription("Mark a Kubernetes Node as schedulable (same as kubectl uncordon), usually once the maintenance of a cordoned or drained Node is complete"),
+			mcp.WithString("name", mcp.Description("Name of the Node to uncordon"), mcp.Required()),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, only check whether the Node would be changed (Optional, defaults to false)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Uncordon"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesUncordon},
+		{Tool: mcp.NewTool("nodes_drain",
+			mcp.WithDescription("Drain a Kubernetes Node in preparation for maintenance (same as kubectl drain): the Node is cordoned and its Pods are evicted through the Eviction API, "+
+				"so that PodDisruptionBudgets are honored (evictions blocked by a budget are retried until the timeout expires). "+
+				"No Pod is evicted if some of them are managed by a DaemonSet, use emptyDir volumes, or aren't managed by a controller, unless the corresponding option is enabled. "+
+				"Use dryRun to check the Pods that would be evicted first, and nodes_uncordon once the maintenance is complete"),
+			mcp.WithString("name", mcp.Description("Name of the Node to drain"), mcp.Required()),
+			mcp.WithBoolean("ignoreDaemonSets", mcp.Description("If true, ignore the DaemonSet-managed Pods, otherwise the drain fails if there are any (Optional, defaults to false)")),
+			mcp.WithBoolean("deleteEmptyDirData", mcp.Description("If true, evict the Pods using emptyDir volumes, their local data is lost (Optional, defaults to false)")),
+			mcp.WithBoolean("force", mcp.Description("If true, evict the Pods that aren't managed by a controller (ReplicaSet, Job, StatefulSet...), they won't be recreated (Optional, defaults to false)")),
+			mcp.WithNumber("gracePeriodSeconds", mcp.Description("Duration in seconds the Pods have to terminate gracefully (Optional, defaults to the Pod termination grace period)"), mcp.Min(0)),
+			mcp.WithString("podSelector", mcp.Description("Kubernetes label selector (e.g. 'app=myapp') to only evict the matching Pods (Optional)"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the Pods to be evicted (Optional, defaults to %d, max %d)", int(kubernetes.DefaultNodesDrainTimeout.Seconds()), nodesDrainMaxTimeout)),
+				mcp.Min(1), mcp.Max(nodesDrainMaxTimeout)),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, only list the Pods that would be evicted, the Node is not cordoned (Optional, defaults to false)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Nodes: Drain"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.nodesDrain},
+	}
+}
+
+func (s *Server) nodesTop(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	nodesTopOptions := kubernetes.NodesTopOptions{}
+	if v, ok := ctr.GetArguments()["name"].(string); ok {
+		nodesTopOptions.Name = v
+	}
+	if v, ok := ctr.GetArguments()["label_selector"].(string); ok {
+		nodesTopOptions.LabelSelector = v
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodeMetrics, allocatable, err := derived.NodesTop(ctx, nodesTopOptions)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get nodes top: %v", err)), nil
+	}
+	if len(nodeMetrics.Items) == 0 {
+		return NewTextResult("No node metrics found", nil), nil
+	}
+	return NewTextResult(printNodesTop(nodeMetrics.Items, allocatable), nil), nil
+}
+
+func (s *Server) nodesCordon(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.nodesCordonOrUncordon(ctx, ctr, true)
+}
+
+func (s *Server) nodesUncordon(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.nodesCordonOrUncordon(ctx, ctr, false)
+}
+
+func (s *Server) nodesCordonOrUncordon(ctx context.Context, ctr mcp.CallToolRequest, unschedulable bool) (*mcp.CallToolResult, error) {
+	action := "cordon"
+	if !unschedulable {
+		action = "uncordon"
+	}
+	name, _ := ctr.GetArguments()["name"].(string)
+	if name == "" {
+		return NewTextResult("", fmt.Errorf("failed to %s node, missing argument name", action)), nil
+	}
+	dryRun, _ := ctr.GetArguments()["dryRun"].(bool)
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := derived.NodesCordon(ctx, name, unschedulable, dryRun)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to %s node %s: %v", action, name, err)), nil
+	}
+	switch {
+	case !changed:
+		return NewTextResult(fmt.Sprintf("Node %s already %sed", name, action), nil), nil
+	case dryRun:
+		return NewTextResult(fmt.Sprintf("Node %s would be %sed (dry run)", name, action), nil), nil
+	}
+	return NewTextResult(fmt.Sprintf("Node %s %sed successfully", name, action), nil), nil
+}
+
+func (s *Server) nodesDrain(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, _ := ctr.GetArguments()["name"].(string)
+	if name == "" {
+		return NewTextResult("", errors.New("failed to drain node, missing argument name")), nil
+	}
+	nodesDrainOptions := kubernetes.NodesDrainOptions{}
+	nodesDrainOptions.IgnoreDaemonSets, _ = ctr.GetArguments()["ignoreDaemonSets"].(bool)
+	nodesDrainOptions.DeleteEmptyDirData, _ = ctr.GetArguments()["deleteEmptyDirData"].(bool)
+	nodesDrainOptions.Force, _ = ctr.GetArguments()["force"].(bool)
+	nodesDrainOptions.PodSelector, _ = ctr.GetArguments()["podSelector"].(string)
+	nodesDrainOptions.DryRun, _ = ctr.GetArguments()["dryRun"].(bool)
+	if v, ok := ctr.GetArguments()["gracePeriodSeconds"].(float64); ok {
+		if v < 0 {
+			return NewTextResult("", errors.New("failed to drain node, gracePeriodSeconds must be greater than or equal to 0")), nil
+		}
+		nodesDrainOptions.GracePeriodSeconds = ptr.To(int64(v))
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v < 1 || v > nodesDrainMaxTimeout {
+			return NewTextResult("", fmt.Errorf("failed to drain node, timeout must be between 1 and %d seconds", nodesDrainMaxTimeout)), nil
+		}
+		nodesDrainOptions.Timeout = time.Duration(v) * time.Second
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := derived.NodesDrain(ctx, name, nodesDrainOptions)
+	if err != nil && result == nil {
+		return NewTextResult("", fmt.Errorf("failed to drain node %s: %v", name, err)), nil
+	} else if err != nil {
+		ret := nodesDrainFlags.Replace(err.Error())
+		if result.Cordoned {
+			ret += "\n# Node " + name + " was cordoned and is left unschedulable"
+		}
+		if len(result.Evicted) > 0 {
+			ret += fmt.Sprintf("\n# %d of %d Pods were evicted\n%s", len(result.Evicted), len(result.Pods), printNodesDrainPods(result.Evicted))
+		}
+		return NewTextResult("", fmt.Errorf("failed to drain node %s: %s", name, ret)), nil
+	}
+	ret := ""
+	switch {
+	case nodesDrainOptions.DryRun && len(result.Pods) == 0:
+		ret = fmt.Sprintf("# Node %s would be drained, no Pods to evict (dry run)\n", name)
+	case nodesDrainOptions.DryRun:
+		ret = fmt.Sprintf("# Node %s would be drained, the following %d Pods would be evicted (dry run)\n", name, len(result.Pods))
+		ret += printNodesDrainPods(result.Pods)
+	default:
+		ret = fmt.Sprintf("# Node %s drained successfully, %d Pods evicted\n", name, len(result.Evicted))
+		ret += printNodesDrainPods(result.Evicted)
+	}
+	if result.Warnings != "" {
+		ret += "# WARNING: " + result.Warnings + "\n"
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+// printNodesDrainPods prints the Pods as a namespace/name list sorted by name
+func printNodesDrainPods(pods []v1.Pod) string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, "- "+pod.Namespace+"/"+pod.Name+"\n")
+	}
+	sort.Strings(names)
+	return strings.Join(names, "")
+}
+
+// printNodesTop prints the Node metrics in the kubectl top node format extended with the allocatable resources
+func printNodesTop(nodeMetrics []metrics.NodeMetrics, allocatable map[string]v1.ResourceList) string {
+	sort.Slice(nodeMetrics, func(i, j int) bool { return nodeMetrics[i].Name < nodeMetrics[j].Name })
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 10, 4, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tCPU(cores)\tCPU(allocatable)\tCPU(%)\tMEMORY(bytes)\tMEMORY(allocatable)\tMEMORY(%)")
+	withMetrics := make(map[string]bool, len(nodeMetrics))
+	for _, m := range nodeMetrics {
+		withMetrics[m.Name] = true
+		nodeAllocatable, found := allocatable[m.Name]
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", m.Name,
+			nodesTopResource(v1.ResourceCPU, m.Usage[v1.ResourceCPU], nodeAllocatable, found),
+			nodesTopResource(v1.ResourceMemory, m.Usage[v1.ResourceMemory], nodeAllocatable, found))
+	}
+	// Nodes for which the metrics are unreachable
+	var withoutMetrics []string
+	for name := range allocatable {
+		if !withMetrics[name] {
+			withoutMetrics = append(withoutMetrics, name)
+		}
+	}
+	sort.Strings(withoutMetrics)
+	for _, name := range withoutMetrics {
+		_, _ = fmt.Fprintf(w, "%s\t<unknown>\t%s\t<unknown>\t<unknown>\t%s\t<unknown>\n", name,
+			nodesTopQuantity(v1.ResourceCPU, allocatable[name][v1.ResourceCPU]),
+			nodesTopQuantity(v1.ResourceMemory, allocatable[name][v1.ResourceMemory]))
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// nodesTopResource returns the usage, allocatable and percentage columns of the provided resource
+func nodesTopResource(name v1.ResourceName, usage resource.Quantity, allocatable v1.ResourceList, found bool) string {
+	quantity, ok := allocatable[name]
+	if !found || !ok || quantity.IsZero() {
+		return nodesTopQuantity(name, usage) + "\t<unknown>\t<unknown>"
+	}
+	fraction := float64(usage.MilliValue()) / float64(quantity.MilliValue()) * 100
+	return fmt.Sprintf("%s\t%s\t%d%%", nodesTopQuantity(name, usage), nodesTopQuantity(name, quantity), int64(fraction))
+}
+
+func nodesTopQuantity(name v1.ResourceName, quantity resource.Quantity) string {
+	if name == v1.ResourceCPU {
+		return fmt.Sprintf("%dm", quantity.MilliValue())
+	}
+	return fmt.Sprintf("%dMi", quantity.Value()/(1024*1024))
+}
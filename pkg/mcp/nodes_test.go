@@ -0,0 +1,309 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestNodesTop(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "metrics.k8s.io/v1beta1", APIResources: []metav1.APIResource{
+				{Name: "nodes", Kind: "NodeMetrics", Verbs: []string{"get", "list"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/apis/metrics.k8s.io/v1beta1/nodes":
+				_, _ = w.Write([]byte(`{"kind":"NodeMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[` +
+					`{"metadata":{"name":"node-2"},"usage":{"cpu":"1500m","memory":"6Gi"}},` +
+					`{"metadata":{"name":"node-1"},"usage":{"cpu":"500m","memory":"2Gi"}}` +
+					`]}`))
+			case "/apis/metrics.k8s.io/v1beta1/nodes/node-1":
+				_, _ = w.Write([]byte(`{"kind":"NodeMetrics","apiVersion":"metrics.k8s.io/v1beta1","metadata":{"name":"node-1"},"usage":{"cpu":"500m","memory":"2Gi"}}`))
+			case "/api/v1/nodes":
+				_, _ = w.Write([]byte(`{"kind":"NodeList","apiVersion":"v1","items":[` +
+					`{"metadata":{"name":"node-1"},"status":{"allocatable":{"cpu":"2","memory":"8Gi"}}},` +
+					`{"metadata":{"name":"node-2"},"status":{"allocatable":{"cpu":"2","memory":"8Gi"}}},` +
+					`{"metadata":{"name":"node-3"},"status":{"allocatable":{"cpu":"4","memory":"16Gi"}}}` +
+					`]}`))
+			case "/api/v1/nodes/node-1":
+				_, _ = w.Write([]byte(`{"kind":"Node","apiVersion":"v1","metadata":{"name":"node-1"},"status":{"allocatable":{"cpu":"2","memory":"8Gi"}}}`))
+			}
+		}))
+		nodesTop, err := c.callTool("nodes_top", map[string]interface{}{})
+		t.Run("nodes_top returns node metrics with allocatable capacity", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			textContent := nodesTop.Content[0].(mcp.TextContent).Text
+			if nodesTop.IsError {
+				t.Fatalf("call tool failed %s", textContent)
+			}
+			expectedHeaders := regexp.MustCompile(`(?m)^NAME\s+CPU\(cores\)\s+CPU\(allocatable\)\s+CPU\(%\)\s+MEMORY\(bytes\)\s+MEMORY\(allocatable\)\s+MEMORY\(%\)\s*$`)
+			if !expectedHeaders.MatchString(textContent) {
+				t.Errorf("Expected headers '%s' not found in output:\n%s", expectedHeaders.String(), textContent)
+			}
+			expectedRows := []string{
+				`(?m)^node-1\s+500m\s+2000m\s+25%\s+2048Mi\s+8192Mi\s+25%\s*$`,
+				`(?m)^node-2\s+1500m\s+2000m\s+75%\s+6144Mi\s+8192Mi\s+75%\s*$`,
+			}
+			for _, row := range expectedRows {
+				if !regexp.MustCompile(row).MatchString(textContent) {
+					t.Errorf("Expected row '%s' not found in output:\n%s", row, textContent)
+				}
+			}
+		})
+		t.Run("nodes_top returns nodes without metrics", func(t *testing.T) {
+			expectedRow := regexp.MustCompile(`(?m)^node-3\s+<unknown>\s+4000m\s+<unknown>\s+<unknown>\s+16384Mi\s+<unknown>\s*$`)
+			if !expectedRow.MatchString(nodesTop.Content[0].(mcp.TextContent).Text) {
+				t.Errorf("Expected row '%s' not found in output:\n%s", expectedRow.String(), nodesTop.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		nodesTopName, err := c.callTool("nodes_top", map[string]interface{}{"name": "node-1"})
+		t.Run("nodes_top[name=node-1] returns metrics for provided node", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			textContent := nodesTopName.Content[0].(mcp.TextContent).Text
+			if !regexp.MustCompile(`(?m)^node-1\s+500m\s+2000m\s+25%`).MatchString(textContent) {
+				t.Errorf("Expected node-1 row not found in output:\n%s", textContent)
+			}
+			if regexp.MustCompile(`node-2|node-3`).MatchString(textContent) {
+				t.Errorf("Unexpected nodes in output:\n%s", textContent)
+			}
+		})
+	})
+}
+
+func TestNodesTopDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "metrics.k8s.io/v1beta1", APIResources: []metav1.APIResource{
+				{Name: "nodes", Kind: "NodeMetrics", Verbs: []string{"get", "list"}},
+			}},
+		))
+		nodesTop, _ := c.callTool("nodes_top", map[string]interface{}{})
+		t.Run("nodes_top has error", func(t *testing.T) {
+			if !nodesTop.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("nodes_top describes denial", func(t *testing.T) {
+			expectedMessage := "failed to get nodes top: resource not allowed: metrics.k8s.io/v1beta1, Kind=NodeMetrics"
+			if nodesTop.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, nodesTop.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestNodesDrain(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var lock sync.Mutex
+		unschedulable := false
+		var nodePatches int
+		evicted := map[string]bool{}
+		pod := func(namespace, name, ownerKind, ownerName string, emptyDir bool) v1.Pod {
+			p := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID("uid-" + name),
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: ownerKind, Name: ownerName, Controller: ptr.To(true)}}},
+				Spec: v1.PodSpec{NodeName: "node-1"}}
+			if emptyDir {
+				p.Spec.Volumes = []v1.Volume{{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+			}
+			return p
+		}
+		pods := []v1.Pod{
+			pod("default", "web-1", "ReplicaSet", "web", false),
+			pod("default", "cache-1", "ReplicaSet", "cache", true),
+			pod("kube-system", "agent-x", "DaemonSet", "agent", false),
+		}
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "nodes", Kind: "Node", Verbs: []string{"get", "list", "patch"}},
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list", "delete"}},
+				{Name: "pods/eviction", Namespaced: true, Group: "policy", Version: "v1", Kind: "Eviction", Verbs: []string{"create"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			node := &v1.Node{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Node"}, ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+			switch {
+			case req.URL.Path == "/api/v1/nodes/node-1" && req.Method == http.MethodPatch:
+				body, _ := io.ReadAll(req.Body)
+				nodePatches++
+				unschedulable = strings.Contains(string(body), `"unschedulable":true`)
+				node.Spec.Unschedulable = unschedulable
+				test.WriteObject(w, node)
+			case req.URL.Path == "/api/v1/nodes/node-1":
+				node.Spec.Unschedulable = unschedulable
+				test.WriteObject(w, node)
+			case req.URL.Path == "/api/v1/pods":
+				if req.URL.Query().Get("fieldSelector") != "spec.nodeName=node-1" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				list := &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}}
+				for _, p := range pods {
+					if !evicted[p.Name] {
+						list.Items = append(list.Items, p)
+					}
+				}
+				test.WriteObject(w, list)
+			case req.URL.Path == "/apis/apps/v1/namespaces/kube-system/daemonsets/agent":
+				test.WriteObject(w, &appsv1.DaemonSet{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"}, ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "agent"}})
+			case strings.HasSuffix(req.URL.Path, "/eviction") && req.Method == http.MethodPost:
+				evicted[path.Base(path.Dir(req.URL.Path))] = true
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+			case strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/") && req.Method == http.MethodGet:
+				for _, p := range pods {
+					if p.Name == path.Base(req.URL.Path) && !evicted[p.Name] {
+						test.WriteObject(w, &p)
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			}
+		}))
+		t.Run("nodes_drain with missing name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_drain", map[string]interface{}{})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to drain node, missing argument name" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("nodes_drain dry run lists the Pods to evict without cordoning the Node", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1", "ignoreDaemonSets": true, "deleteEmptyDirData": true, "dryRun": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# Node node-1 would be drained, the following 2 Pods would be evicted (dry run)\n" +
+				"- default/cache-1\n" +
+				"- default/web-1\n" +
+				"# WARNING: ignoring DaemonSet-managed Pods: kube-system/agent-x\n"
+			if text := toolResult.Content[0].(mcp.TextContent).Text; text != expected {
+				t.Errorf("unexpected result, got\n%v", text)
+			}
+			if nodePatches > 0 || len(evicted) > 0 {
+				t.Errorf("dry run should not change the cluster, got %d node patches and %v evicted", nodePatches, evicted)
+			}
+		})
+		t.Run("nodes_drain with DaemonSet and emptyDir Pods fails and leaves the Node cordoned", func(t *testing.T) {
+			toolResult, _ := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1"})
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail, got %v", text)
+			}
+			for _, expected := range []string{
+				"cannot delete DaemonSet-managed Pods (use ignoreDaemonSets to ignore): kube-system/agent-x",
+				"cannot delete Pods with local storage (use deleteEmptyDirData to override): default/cache-1",
+				"# Node node-1 was cordoned and is left unschedulable",
+			} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q, got %v", expected, text)
+				}
+			}
+			if !unschedulable || len(evicted) > 0 {
+				t.Errorf("expected cordoned Node and no evictions, got unschedulable %v and %v evicted", unschedulable, evicted)
+			}
+		})
+		t.Run("nodes_cordon with cordoned Node does nothing", func(t *testing.T) {
+			nodePatches = 0
+			toolResult, err := c.callTool("nodes_cordon", map[string]interface{}{"name": "node-1"})
+			if err != nil || toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "Node node-1 already cordoned" {
+				t.Fatalf("unexpected result %v %v", err, toolResult.Content)
+			}
+			if nodePatches > 0 {
+				t.Errorf("expected no node patches, got %d", nodePatches)
+			}
+		})
+		t.Run("nodes_drain evicts the Pods", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1", "ignoreDaemonSets": true, "deleteEmptyDirData": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# Node node-1 drained successfully, 2 Pods evicted\n" +
+				"- default/cache-1\n" +
+				"- default/web-1\n" +
+				"# WARNING: ignoring DaemonSet-managed Pods: kube-system/agent-x\n"
+			if text := toolResult.Content[0].(mcp.TextContent).Text; text != expected {
+				t.Errorf("unexpected result, got\n%v", text)
+			}
+			if !evicted["web-1"] || !evicted["cache-1"] || evicted["agent-x"] {
+				t.Errorf("expected web-1 and cache-1 to be evicted, got %v", evicted)
+			}
+		})
+		t.Run("nodes_uncordon marks the Node as schedulable", func(t *testing.T) {
+			toolResult, err := c.callTool("nodes_uncordon", map[string]interface{}{"name": "node-1"})
+			if err != nil || toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "Node node-1 uncordoned successfully" {
+				t.Fatalf("unexpected result %v %v", err, toolResult.Content)
+			}
+			if unschedulable {
+				t.Errorf("expected schedulable Node")
+			}
+		})
+	})
+}
+
+func TestNodesDrainDenied(t *testing.T) {
+	for _, denied := range []config.GroupVersionKind{
+		{Version: "v1", Kind: "Pod"},
+		{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		{Group: "policy", Version: "v1", Kind: "Eviction"},
+	} {
+		deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{denied}}
+		testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+			mockServer := test.NewMockServer()
+			defer mockServer.Close()
+			c.withKubeConfig(mockServer.Config())
+			mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if req.URL.Path == "/api/v1/nodes/node-1" {
+					test.WriteObject(w, &v1.Node{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Node"}, ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+				}
+			}))
+			nodesDrain, _ := c.callTool("nodes_drain", map[string]interface{}{"name": "node-1"})
+			t.Run("nodes_drain describes denial of "+denied.Kind, func(t *testing.T) {
+				expectedMessage := "failed to drain node node-1: resource not allowed: " + denied.Group + "/" + denied.Version + ", Kind=" + denied.Kind
+				if !nodesDrain.IsError || nodesDrain.Content[0].(mcp.TextContent).Text != expectedMessage {
+					t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, nodesDrain.Content[0].(mcp.TextContent).Text)
+				}
+			})
+			nodesCordon, _ := c.callTool("nodes_cordon", map[string]interface{}{"name": "node-1"})
+			t.Run("nodes_cordon only requires Nodes with denied "+denied.Kind, func(t *testing.T) {
+				if nodesCordon.IsError || nodesCordon.Content[0].(mcp.TextContent).Text != "Node node-1 cordoned successfully" {
+					t.Fatalf("unexpected result %v", nodesCordon.Content)
+				}
+			})
+		})
+	}
+}
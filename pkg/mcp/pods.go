@@ -3,22 +3,45 @@ package mcp
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubectl/pkg/metricsutil"
+	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
 )
 
+const (
+	// podsCopyDefaultMaxSize is the default maximum size of the content copied from a Pod container
+	podsCopyDefaultMaxSize = 10 * 1024 * 1024
+	// podsCopyTextMaxSize is the maximum size of the files that are returned as text content
+	podsCopyTextMaxSize = 64 * 1024
+	// podsRunDefaultWaitTimeout is the default maximum time in seconds to wait for a Pod started with pods_run
+	podsRunDefaultWaitTimeout = 60
+	// podsLogMaxFollowDuration is the maximum time in seconds the logs can be followed in a single pods_log call
+	podsLogMaxFollowDuration = 300
+)
+
 func (s *Server) initPods() []server.ServerTool {
 	return []server.ServerTool{
 		{Tool: mcp.NewTool("pods_list",
 			mcp.WithDescription("List all the Kubernetes pods in the current cluster from all namespaces"),
 			mcp.WithString("labelSelector", mcp.Description("Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("fieldSelector", mcp.Description("Optional Kubernetes field selector (e.g. 'status.phase=Running' or 'spec.nodeName=node-1,status.phase!=Succeeded'), use this option when you want to filter the pods by field")),
+			mcp.WithNumber("limit", mcp.Description("Optional maximum number of pods to return, if more pods are available the result includes a continue token to retrieve the next page")),
+			mcp.WithString("continue", mcp.Description("Optional continue token returned by a previous call with limit, use this option to retrieve the next page of pods")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: List"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -29,6 +52,9 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithDescription("List all the Kubernetes pods in the specified namespace in the current cluster"),
 			mcp.WithString("namespace", mcp.Description("Namespace to list pods from"), mcp.Required()),
 			mcp.WithString("labelSelector", mcp.Description("Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("fieldSelector", mcp.Description("Optional Kubernetes field selector (e.g. 'status.phase=Running' or 'spec.nodeName=node-1,status.phase!=Succeeded'), use this option when you want to filter the pods by field")),
+			mcp.WithNumber("limit", mcp.Description("Optional maximum number of pods to return, if more pods are available the result includes a continue token to retrieve the next page")),
+			mcp.WithString("continue", mcp.Description("Optional continue token returned by a previous call with limit, use this option to retrieve the next page of pods")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: List in Namespace"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -46,9 +72,16 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.podsGet},
 		{Tool: mcp.NewTool("pods_delete",
-			mcp.WithDescription("Delete a Kubernetes Pod in the current or provided namespace with the provided name"),
+			mcp.WithDescription("Delete a Kubernetes Pod in the current or provided namespace with the provided name, or all the Pods matching the provided label selector. "+
+				"Pods can be evicted through the Eviction API so that PodDisruptionBudgets are honored"),
 			mcp.WithString("namespace", mcp.Description("Namespace to delete the Pod from")),
-			mcp.WithString("name", mcp.Description("Name of the Pod to delete"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the Pod to delete (Optional, required if labelSelector is not provided)")),
+			mcp.WithString("labelSelector", mcp.Description("Kubernetes label selector (e.g. 'app=myapp,env=prod') of the Pods to delete (Optional, can't be combined with name)"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithNumber("gracePeriodSeconds", mcp.Description("Duration in seconds the Pods have to terminate gracefully (Optional, defaults to the Pod termination grace period)")),
+			mcp.WithBoolean("force", mcp.Description("If true, delete the Pods immediately without waiting for confirmation that they were terminated (grace period of 0). "+
+				"Can't be combined with evict (Optional, defaults to false)")),
+			mcp.WithBoolean("evict", mcp.Description("If true, evict the Pods through the Eviction API so that PodDisruptionBudgets are honored (Optional, defaults to false)")),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, only list the Pods that would be deleted or evicted (Optional, defaults to false)")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Delete"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -56,12 +89,28 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.podsDelete},
+		{Tool: mcp.NewTool("pods_diagnose",
+			mcp.WithDescription("Diagnose why a Kubernetes Pod in the current or provided namespace is unhealthy. "+
+				"Collects the Pod conditions, container states and last termination reasons, restart counts, probe definitions, scheduling failures, "+
+				"the events involving the Pod, and the tail of the previous container logs, and returns a structured summary with the probable causes "+
+				"(e.g. ImagePullBackOff, OOMKilled, failing probes, Unschedulable, missing ConfigMap or Secret)"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod to diagnose")),
+			mcp.WithString("name", mcp.Description("Name of the Pod to diagnose"), mcp.Required()),
+			mcp.WithNumber("tail", mcp.Description(fmt.Sprintf("Number of lines to retrieve from the end of the previous container logs (Optional, defaults to %d)", kubernetes.DefaultPodsDiagnoseLogTailLines))),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Pods: Diagnose"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.podsDiagnose},
 		{Tool: mcp.NewTool("pods_top",
 			mcp.WithDescription("List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Pods in the all namespaces, the provided namespace, or the current namespace"),
 			mcp.WithBoolean("all_namespaces", mcp.Description("If true, list the resource consumption for all Pods in all namespaces. If false, list the resource consumption for Pods in the provided namespace or the current namespace"), mcp.DefaultBool(true)),
 			mcp.WithString("namespace", mcp.Description("Namespace to get the Pods resource consumption from (Optional, current namespace if not provided and all_namespaces is false)")),
 			mcp.WithString("name", mcp.Description("Name of the Pod to get the resource consumption from (Optional, all Pods in the namespace if not provided)")),
 			mcp.WithString("label_selector", mcp.Description("Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label (Optional, only applicable when name is not provided)"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithBoolean("containers", mcp.Description("If true, break down the resource consumption per container. If false, only list the resource consumption totals per Pod"), mcp.DefaultBool(true)),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Top"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -76,17 +125,12 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithArray("command", mcp.Description("Command to execute in the Pod container. "+
 				"The first item is the command to be run, and the rest are the arguments to that command. "+
 				`Example: ["ls", "-l", "/tmp"]`),
-				// TODO: manual fix to ensure that the items property gets initialized (Gemini)
-				// https://www.googlecloudcommunity.com/gc/AI-ML/Gemini-API-400-Bad-Request-Array-fields-breaks-function-calling/m-p/769835?nobounce
-				func(schema map[string]interface{}) {
-					schema["type"] = "array"
-					schema["items"] = map[string]interface{}{
-						"type": "string",
-					}
-				},
+				stringItems,
 				mcp.Required(),
 			),
 			mcp.WithString("container", mcp.Description("Name of the Pod container where the command will be executed (Optional)")),
+			mcp.WithString("stdin", mcp.Description("Content to send to the standard input of the command (Optional)")),
+			mcp.WithNumber("timeout", mcp.Description("Maximum time in seconds to wait for the command to complete (Optional, no timeout if not provided)")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Exec"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -94,11 +138,76 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithIdempotentHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.podsExec},
+		{Tool: mcp.NewTool("pods_debug",
+			mcp.WithDescription("Debug a running Kubernetes Pod in the current or provided namespace by attaching an ephemeral container with the provided image "+
+				"that runs the provided command, and return its output (equivalent to kubectl debug --target). "+
+				"Useful for Pods with distroless images where pods_exec can't be used. "+
+				"Ephemeral containers can't be removed and remain in the Pod spec once they terminate"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod to debug")),
+			mcp.WithString("name", mcp.Description("Name of the Pod to debug"), mcp.Required()),
+			mcp.WithString("image", mcp.Description("Container image of the ephemeral debug container. Example: busybox"), mcp.Required()),
+			mcp.WithArray("command", mcp.Description("Command to run in the ephemeral debug container. "+
+				"The first item is the command to be run, and the rest are the arguments to that command. "+
+				`Example: ["ps", "aux"]`),
+				stringItems,
+				mcp.Required(),
+			),
+			mcp.WithString("target", mcp.Description("Name of the Pod container whose process namespace is shared with the debug container (Optional)")),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum time in seconds to wait for the command to complete (Optional, defaults to %d)", int(kubernetes.DefaultPodsDebugTimeout.Seconds())))),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Pods: Debug"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true), // Ephemeral containers can't be removed and may affect the Pod's processes
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.podsDebug},
+		{Tool: mcp.NewTool("pods_copy_from",
+			mcp.WithDescription("Copy a file or directory from a Kubernetes Pod container in the current or provided namespace (equivalent to kubectl cp, requires tar in the container). "+
+				"Small text files are returned as text, binary or large files are returned as embedded resources with base64 content"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod to copy the file from")),
+			mcp.WithString("name", mcp.Description("Name of the Pod to copy the file from"), mcp.Required()),
+			mcp.WithString("container", mcp.Description("Name of the Pod container to copy the file from (Optional)")),
+			mcp.WithString("path", mcp.Description("Absolute path of the file or directory in the container to copy"), mcp.Required()),
+			mcp.WithNumber("maxSize", mcp.Description(fmt.Sprintf("Maximum size in bytes of the copied content (Optional, defaults to %d)", podsCopyDefaultMaxSize))),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Pods: Copy From"),
+			mcp.WithReadOnlyHintAnnotation(false), // Runs tar in the Pod container through exec, same as pods_exec
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.podsCopyFrom},
+		{Tool: mcp.NewTool("pods_copy_to",
+			mcp.WithDescription("Copy content into a file of a Kubernetes Pod container in the current or provided namespace (equivalent to kubectl cp, requires tar in the container). "+
+				"The file is created or overwritten"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod to copy the file to")),
+			mcp.WithString("name", mcp.Description("Name of the Pod to copy the file to"), mcp.Required()),
+			mcp.WithString("container", mcp.Description("Name of the Pod container to copy the file to (Optional)")),
+			mcp.WithString("path", mcp.Description("Absolute path of the file in the container, the parent directory must exist"), mcp.Required()),
+			mcp.WithString("content", mcp.Description("Content of the file"), mcp.Required()),
+			mcp.WithString("encoding", mcp.Description("Encoding of the provided content, use base64 for binary files (Optional, defaults to text)"), mcp.Enum("text", "base64")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Pods: Copy To"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.podsCopyTo},
 		{Tool: mcp.NewTool("pods_log",
 			mcp.WithDescription("Get the logs of a Kubernetes Pod in the current or provided namespace with the provided name"),
 			mcp.WithString("namespace", mcp.Description("Namespace to get the Pod logs from")),
 			mcp.WithString("name", mcp.Description("Name of the Pod to get the logs from"), mcp.Required()),
 			mcp.WithString("container", mcp.Description("Name of the Pod container to get the logs from (Optional)")),
+			mcp.WithBoolean("previous", mcp.Description("If true, return the logs of the previous terminated container instance, useful to debug containers in CrashLoopBackOff (Optional)")),
+			mcp.WithNumber("tail", mcp.Description(fmt.Sprintf("Number of lines to retrieve from the end of the logs (Optional, defaults to %d, use -1 to retrieve all the lines)", kubernetes.DefaultPodsLogTailLines))),
+			mcp.WithNumber("sinceSeconds", mcp.Description("Only return logs newer than a relative duration in seconds (Optional, mutually exclusive with sinceTime)")),
+			mcp.WithString("sinceTime", mcp.Description("Only return logs after a specific date in RFC3339 format, e.g. 2025-01-01T10:00:00Z (Optional, mutually exclusive with sinceSeconds)")),
+			mcp.WithBoolean("timestamps", mcp.Description("If true, prefix every line of the log output with an RFC3339 timestamp (Optional)")),
+			mcp.WithNumber("limitBytes", mcp.Description("Maximum number of bytes of logs to return (Optional)")),
+			mcp.WithBoolean("follow", mcp.Description("If true, keep the log stream open and send every new line to the client as a progress notification "+
+				"(or as a logging notification if no progress token is provided) until followDuration elapses or followMaxLines are received. "+
+				"The received lines are also returned once the call completes (Optional, defaults to false)")),
+			mcp.WithNumber("followDuration", mcp.Description(fmt.Sprintf("Maximum time in seconds to follow the logs when follow is true (Optional, defaults to %d, maximum %d)",
+				int(kubernetes.DefaultPodsLogFollowDuration.Seconds()), podsLogMaxFollowDuration))),
+			mcp.WithNumber("followMaxLines", mcp.Description("Maximum number of lines to receive before closing the log stream when follow is true (Optional, no limit if not provided)")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Log"),
 			mcp.WithReadOnlyHintAnnotation(true),
@@ -111,6 +220,24 @@ func (s *Server) initPods() []server.ServerTool {
 			mcp.WithString("name", mcp.Description("Name of the Pod (Optional, random name if not provided)")),
 			mcp.WithString("image", mcp.Description("Container Image to run in the Pod"), mcp.Required()),
 			mcp.WithNumber("port", mcp.Description("TCP/IP port to expose from the Pod container (Optional, no port exposed if not provided)")),
+			mcp.WithArray("command", mcp.Description("Entrypoint of the Pod container, overrides the image ENTRYPOINT (Optional). "+
+				`Example: ["sh", "-c"]`), stringItems),
+			mcp.WithArray("args", mcp.Description("Arguments to the entrypoint of the Pod container, overrides the image CMD (Optional). "+
+				`Example: ["echo hello"]`), stringItems),
+			mcp.WithObject("env", mcp.Description("Environment variables to set in the Pod container as name-value pairs (Optional). "+
+				`Example: {"LOG_LEVEL": "debug"}`)),
+			mcp.WithObject("requests", mcp.Description("Compute resource requests of the Pod container (Optional). "+
+				`Example: {"cpu": "100m", "memory": "128Mi"}`)),
+			mcp.WithObject("limits", mcp.Description("Compute resource limits of the Pod container (Optional). "+
+				`Example: {"cpu": "500m", "memory": "256Mi"}`)),
+			mcp.WithString("restartPolicy", mcp.Description("Restart policy of the Pod (Optional, defaults to Always)"), mcp.Enum("Always", "OnFailure", "Never")),
+			mcp.WithString("serviceAccountName", mcp.Description("Name of the ServiceAccount to run the Pod as (Optional)")),
+			mcp.WithObject("labels", mcp.Description("Additional labels to set in the Pod (Optional). "+
+				`Example: {"app": "my-app"}`)),
+			mcp.WithString("imagePullPolicy", mcp.Description("Image pull policy of the Pod container (Optional, defaults to Always)"), mcp.Enum("Always", "IfNotPresent", "Never")),
+			mcp.WithBoolean("wait", mcp.Description("If true, wait until the Pod is Ready or has terminated and return its status, "+
+				"and its logs if it ran to completion (Optional, defaults to false)")),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum time in seconds to wait for the Pod when wait is true (Optional, defaults to %d)", podsRunDefaultWaitTimeout))),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Pods: Run"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -122,12 +249,13 @@ func (s *Server) initPods() []server.ServerTool {
 }
 
 func (s *Server) podsListInAllNamespaces(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	labelSelector := ctr.GetArguments()["labelSelector"]
-	resourceListOptions := kubernetes.ResourceListOptions{
-		AsTable: s.configuration.ListOutput.AsTable(),
+	listOptions, err := parseListOptions(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list pods in all namespaces, %v", err)), nil
 	}
-	if labelSelector != nil {
-		resourceListOptions.LabelSelector = labelSelector.(string)
+	resourceListOptions := kubernetes.ResourceListOptions{
+		ListOptions: listOptions,
+		AsTable:     s.configuration.ListOutput.AsTable(),
 	}
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
@@ -137,7 +265,7 @@ func (s *Server) podsListInAllNamespaces(ctx context.Context, ctr mcp.CallToolRe
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list pods in all namespaces: %v", err)), nil
 	}
-	return NewTextResult(s.configuration.ListOutput.PrintObj(ret)), nil
+	return NewTextResult(printList(ret, s.configuration.ListOutput.PrintObj)), nil
 }
 
 func (s *Server) podsListInNamespace(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -145,12 +273,13 @@ func (s *Server) podsListInNamespace(ctx context.Context, ctr mcp.CallToolReques
 	if ns == nil {
 		return NewTextResult("", errors.New("failed to list pods in namespace, missing argument namespace")), nil
 	}
-	resourceListOptions := kubernetes.ResourceListOptions{
-		AsTable: s.configuration.ListOutput.AsTable(),
+	listOptions, err := parseListOptions(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list pods in namespace %s, %v", ns, err)), nil
 	}
-	labelSelector := ctr.GetArguments()["labelSelector"]
-	if labelSelector != nil {
-		resourceListOptions.LabelSelector = labelSelector.(string)
+	resourceListOptions := kubernetes.ResourceListOptions{
+		ListOptions: listOptions,
+		AsTable:     s.configuration.ListOutput.AsTable(),
 	}
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
@@ -160,7 +289,7 @@ func (s *Server) podsListInNamespace(ctx context.Context, ctr mcp.CallToolReques
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list pods in namespace %s: %v", ns, err)), nil
 	}
-	return NewTextResult(s.configuration.ListOutput.PrintObj(ret)), nil
+	return NewTextResult(printList(ret, s.configuration.ListOutput.PrintObj)), nil
 }
 
 func (s *Server) podsGet(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -184,23 +313,89 @@ func (s *Server) podsGet(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 }
 
 func (s *Server) podsDelete(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns := ctr.GetArguments()["namespace"]
+	if ns == nil {
+		ns = ""
+	}
+	podsDeleteOptions := kubernetes.PodsDeleteOptions{}
+	if v, ok := ctr.GetArguments()["name"].(string); ok {
+		podsDeleteOptions.Name = v
+	}
+	if v, ok := ctr.GetArguments()["labelSelector"].(string); ok {
+		podsDeleteOptions.LabelSelector = v
+	}
+	if podsDeleteOptions.Name == "" && podsDeleteOptions.LabelSelector == "" {
+		return NewTextResult("", errors.New("failed to delete pod, missing argument name or labelSelector")), nil
+	}
+	if podsDeleteOptions.Name != "" && podsDeleteOptions.LabelSelector != "" {
+		return NewTextResult("", errors.New("failed to delete pod, name can't be combined with labelSelector")), nil
+	}
+	if v, ok := ctr.GetArguments()["gracePeriodSeconds"].(float64); ok {
+		if v < 0 {
+			return NewTextResult("", errors.New("failed to delete pod, gracePeriodSeconds must be greater than or equal to 0")), nil
+		}
+		podsDeleteOptions.GracePeriodSeconds = ptr.To(int64(v))
+	}
+	if v, ok := ctr.GetArguments()["force"].(bool); ok {
+		podsDeleteOptions.Force = v
+	}
+	if v, ok := ctr.GetArguments()["evict"].(bool); ok {
+		podsDeleteOptions.Evict = v
+	}
+	if v, ok := ctr.GetArguments()["dryRun"].(bool); ok {
+		podsDeleteOptions.DryRun = v
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := derived.PodsDelete(ctx, ns.(string), podsDeleteOptions)
+	if err != nil && podsDeleteOptions.Name != "" {
+		return NewTextResult("", fmt.Errorf("failed to delete pod %s in namespace %s: %v", podsDeleteOptions.Name, ns, err)), nil
+	}
+	if err != nil && ret == "" {
+		return NewTextResult("", fmt.Errorf("failed to delete pods matching label selector %s in namespace %s: %v", podsDeleteOptions.LabelSelector, ns, err)), nil
+	}
+	// Partial failures of bulk deletions include the summary of the Pods that were deleted
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("%s\nfailed to delete pods: %v", ret, err)), nil
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+func (s *Server) podsDiagnose(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	ns := ctr.GetArguments()["namespace"]
 	if ns == nil {
 		ns = ""
 	}
 	name := ctr.GetArguments()["name"]
 	if name == nil {
-		return NewTextResult("", errors.New("failed to delete pod, missing argument name")), nil
+		return NewTextResult("", errors.New("failed to diagnose pod, missing argument name")), nil
+	}
+	var tail int64
+	if v, ok := ctr.GetArguments()["tail"].(float64); ok {
+		if v <= 0 {
+			return NewTextResult("", errors.New("failed to diagnose pod, tail must be greater than 0")), nil
+		}
+		tail = int64(v)
 	}
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
 		return nil, err
 	}
-	ret, err := derived.PodsDelete(ctx, ns.(string), name.(string))
+	ret, err := derived.PodsDiagnose(ctx, ns.(string), name.(string), tail)
 	if err != nil {
-		return NewTextResult("", fmt.Errorf("failed to delete pod %s in namespace %s: %v", name, ns, err)), nil
+		return NewTextResult("", fmt.Errorf("failed to diagnose pod %s in namespace %s: %v", name, ns, err)), nil
 	}
-	return NewTextResult(ret, err), nil
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to diagnose pod %s in namespace %s: %v", name, ns, err)), nil
+	}
+	header := fmt.Sprintf("# Found %d probable causes for the problems of pod %s in namespace %s", len(ret.ProbableCauses), ret.Name, ret.Namespace)
+	if len(ret.ProbableCauses) == 0 {
+		header = fmt.Sprintf("# No problems detected for pod %s in namespace %s", ret.Name, ret.Namespace)
+	}
+	return NewTextResult(header+"\n"+marshalledYaml, nil), nil
 }
 
 func (s *Server) podsTop(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -217,6 +412,10 @@ func (s *Server) podsTop(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	if v, ok := ctr.GetArguments()["label_selector"].(string); ok {
 		podsTopOptions.LabelSelector = v
 	}
+	printContainers := true
+	if v, ok := ctr.GetArguments()["containers"].(bool); ok {
+		printContainers = v
+	}
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
 		return nil, err
@@ -227,7 +426,7 @@ func (s *Server) podsTop(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	}
 	buf := new(bytes.Buffer)
 	printer := metricsutil.NewTopCmdPrinter(buf)
-	err = printer.PrintPodMetrics(ret.Items, true, true, false, "", true)
+	err = printer.PrintPodMetrics(ret.Items, printContainers, true, false, "", true)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to get pods top: %v", err)), nil
 	}
@@ -243,32 +442,185 @@ func (s *Server) podsExec(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Ca
 	if name == nil {
 		return NewTextResult("", errors.New("failed to exec in pod, missing argument name")), nil
 	}
-	container := ctr.GetArguments()["container"]
-	if container == nil {
-		container = ""
+	podsExecOptions := kubernetes.PodsExecOptions{}
+	if v, ok := ctr.GetArguments()["container"].(string); ok {
+		podsExecOptions.Container = v
 	}
 	commandArg := ctr.GetArguments()["command"]
-	command := make([]string, 0)
 	if _, ok := commandArg.([]interface{}); ok {
 		for _, cmd := range commandArg.([]interface{}) {
 			if _, ok := cmd.(string); ok {
-				command = append(command, cmd.(string))
+				podsExecOptions.Command = append(podsExecOptions.Command, cmd.(string))
 			}
 		}
 	} else {
 		return NewTextResult("", errors.New("failed to exec in pod, invalid command argument")), nil
 	}
+	if v, ok := ctr.GetArguments()["stdin"].(string); ok {
+		podsExecOptions.Stdin = v
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v <= 0 {
+			return NewTextResult("", errors.New("failed to exec in pod, timeout must be greater than 0")), nil
+		}
+		podsExecOptions.Timeout = time.Duration(v * float64(time.Second))
+	}
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
 		return nil, err
 	}
-	ret, err := derived.PodsExec(ctx, ns.(string), name.(string), container.(string), command)
+	ret, err := derived.PodsExec(ctx, ns.(string), name.(string), podsExecOptions)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to exec in pod %s in namespace %s: %v", name, ns, err)), nil
-	} else if ret == "" {
-		ret = fmt.Sprintf("The executed command in pod %s in namespace %s has not produced any output", name, ns)
 	}
-	return NewTextResult(ret, err), nil
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to exec in pod %s in namespace %s: %v", name, ns, err)), nil
+	}
+	header := fmt.Sprintf("# The executed command in pod %s in namespace %s exited with code %d", name, ns, ret.ExitCode)
+	if ret.Stdout == "" && ret.Stderr == "" {
+		header += " and has not produced any output"
+	}
+	result := NewTextResult(header+"\n"+marshalledYaml, nil)
+	// A non-zero exit code means that the command failed
+	result.IsError = ret.ExitCode != 0
+	return result, nil
+}
+
+func (s *Server) podsDebug(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns := ctr.GetArguments()["namespace"]
+	if ns == nil {
+		ns = ""
+	}
+	name := ctr.GetArguments()["name"]
+	if name == nil {
+		return NewTextResult("", errors.New("failed to debug pod, missing argument name")), nil
+	}
+	image := ctr.GetArguments()["image"]
+	if image == nil {
+		return NewTextResult("", errors.New("failed to debug pod, missing argument image")), nil
+	}
+	podsDebugOptions := kubernetes.PodsDebugOptions{
+		Image:   image.(string),
+		Command: stringSlice(ctr.GetArguments()["command"]),
+	}
+	if len(podsDebugOptions.Command) == 0 {
+		return NewTextResult("", errors.New("failed to debug pod, missing argument command")), nil
+	}
+	if v, ok := ctr.GetArguments()["target"].(string); ok {
+		podsDebugOptions.Target = v
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v <= 0 {
+			return NewTextResult("", errors.New("failed to debug pod, timeout must be greater than 0")), nil
+		}
+		podsDebugOptions.Timeout = time.Duration(v * float64(time.Second))
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := derived.PodsDebug(ctx, ns.(string), name.(string), podsDebugOptions)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to debug pod %s in namespace %s: %v", name, ns, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to debug pod %s in namespace %s: %v", name, ns, err)), nil
+	}
+	header := fmt.Sprintf("# The command in debug container %s of pod %s in namespace %s exited with code %d", ret.Container, name, ns, ret.ExitCode)
+	if ret.Output == "" {
+		header += " and has not produced any output"
+	}
+	result := NewTextResult(header+"\n"+marshalledYaml, nil)
+	// A non-zero exit code means that the command failed
+	result.IsError = ret.ExitCode != 0
+	return result, nil
+}
+
+func (s *Server) podsCopyFrom(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		ns = v
+	}
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok {
+		return NewTextResult("", errors.New("failed to copy from pod, missing argument name")), nil
+	}
+	container := ""
+	if v, ok := ctr.GetArguments()["container"].(string); ok {
+		container = v
+	}
+	filePath, ok := ctr.GetArguments()["path"].(string)
+	if !ok {
+		return NewTextResult("", errors.New("failed to copy from pod, missing argument path")), nil
+	}
+	maxSize := int64(podsCopyDefaultMaxSize)
+	if v, ok := ctr.GetArguments()["maxSize"].(float64); ok && v > 0 {
+		maxSize = int64(v)
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ns = derived.NamespaceOrDefault(ns)
+	files, err := derived.PodsCopyFrom(ctx, ns, name, container, filePath, maxSize)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy %s from pod %s in namespace %s: %v", filePath, name, ns, err)), nil
+	}
+	result := &mcp.CallToolResult{}
+	for _, file := range files {
+		mimeType := http.DetectContentType(file.Content)
+		if len(file.Content) <= podsCopyTextMaxSize && utf8.Valid(file.Content) && !bytes.ContainsRune(file.Content, 0) {
+			result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("# %s (%d bytes)\n%s", file.Path, len(file.Content), file.Content)))
+			continue
+		}
+		result.Content = append(result.Content, mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+			URI:      fmt.Sprintf("k8s://namespaces/%s/pods/%s%s", ns, name, file.Path),
+			MIMEType: mimeType,
+			Blob:     base64.StdEncoding.EncodeToString(file.Content),
+		}))
+	}
+	return result, nil
+}
+
+func (s *Server) podsCopyTo(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ns := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		ns = v
+	}
+	name, ok := ctr.GetArguments()["name"].(string)
+	if !ok {
+		return NewTextResult("", errors.New("failed to copy to pod, missing argument name")), nil
+	}
+	container := ""
+	if v, ok := ctr.GetArguments()["container"].(string); ok {
+		container = v
+	}
+	filePath, ok := ctr.GetArguments()["path"].(string)
+	if !ok {
+		return NewTextResult("", errors.New("failed to copy to pod, missing argument path")), nil
+	}
+	content, ok := ctr.GetArguments()["content"].(string)
+	if !ok {
+		return NewTextResult("", errors.New("failed to copy to pod, missing argument content")), nil
+	}
+	data := []byte(content)
+	if encoding, _ := ctr.GetArguments()["encoding"].(string); encoding == "base64" {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(content); err != nil {
+			return NewTextResult("", fmt.Errorf("failed to copy to pod, invalid base64 content: %v", err)), nil
+		}
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ns = derived.NamespaceOrDefault(ns)
+	if err = derived.PodsCopyTo(ctx, ns, name, container, filePath, data); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to copy %s to pod %s in namespace %s: %v", filePath, name, ns, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("%d bytes copied to %s in pod %s in namespace %s", len(data), filePath, name, ns), nil), nil
 }
 
 func (s *Server) podsLog(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -280,15 +632,26 @@ func (s *Server) podsLog(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	if name == nil {
 		return NewTextResult("", errors.New("failed to get pod log, missing argument name")), nil
 	}
-	container := ctr.GetArguments()["container"]
-	if container == nil {
-		container = ""
+	podsLogOptions, err := parsePodsLogOptions(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get pod log, %v", err)), nil
 	}
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
 		return nil, err
 	}
-	ret, err := derived.PodsLog(ctx, ns.(string), name.(string), container.(string))
+	var ret string
+	if follow, ok := ctr.GetArguments()["follow"].(bool); ok && follow {
+		podsLogFollowOptions, followErr := parsePodsLogFollowOptions(ctr.GetArguments())
+		if followErr != nil {
+			return NewTextResult("", fmt.Errorf("failed to get pod log, %v", followErr)), nil
+		}
+		podsLogFollowOptions.PodsLogOptions = podsLogOptions
+		podsLogFollowOptions.OnLine = podsLogNotifier(ctx, ctr, fmt.Sprintf("pods/%s/%s", derived.NamespaceOrDefault(ns.(string)), name))
+		ret, err = derived.PodsLogFollow(ctx, ns.(string), name.(string), podsLogFollowOptions)
+	} else {
+		ret, err = derived.PodsLog(ctx, ns.(string), name.(string), podsLogOptions)
+	}
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to get pod %s log in namespace %s: %v", name, ns, err)), nil
 	} else if ret == "" {
@@ -310,15 +673,23 @@ func (s *Server) podsRun(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	if image == nil {
 		return NewTextResult("", errors.New("failed to run pod, missing argument image")), nil
 	}
-	port := ctr.GetArguments()["port"]
-	if port == nil {
-		port = float64(0)
+	podsRunOptions, err := parsePodsRunOptions(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to run pod, %v", err)), nil
+	}
+	podsRunOptions.Image = image.(string)
+	waitTimeout := time.Duration(podsRunDefaultWaitTimeout) * time.Second
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v <= 0 {
+			return NewTextResult("", errors.New("failed to run pod, timeout must be greater than 0")), nil
+		}
+		waitTimeout = time.Duration(v * float64(time.Second))
 	}
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
 		return nil, err
 	}
-	resources, err := derived.PodsRun(ctx, ns.(string), name.(string), image.(string), int32(port.(float64)))
+	resources, err := derived.PodsRun(ctx, ns.(string), name.(string), podsRunOptions)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to run pod %s in namespace %s: %v", name, ns, err)), nil
 	}
@@ -326,5 +697,198 @@ func (s *Server) podsRun(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.Cal
 	if err != nil {
 		err = fmt.Errorf("failed to run pod: %v", err)
 	}
-	return NewTextResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+	ret := "# The following resources (YAML) have been created or updated successfully\n" + marshalledYaml
+	if wait, ok := ctr.GetArguments()["wait"].(bool); !ok || !wait || err != nil || len(resources) == 0 {
+		return NewTextResult(ret, err), nil
+	}
+	// The Pod is always the first created resource
+	podName, podNamespace := resources[0].GetName(), resources[0].GetNamespace()
+	pod, err := derived.PodsWait(ctx, podNamespace, podName, waitTimeout)
+	if err != nil {
+		return NewTextResult(ret, fmt.Errorf("failed to wait for pod %s in namespace %s: %v", podName, podNamespace, err)), nil
+	}
+	marshalledStatus, err := output.MarshalYaml(pod.Status)
+	if err != nil {
+		return NewTextResult(ret, fmt.Errorf("failed to wait for pod %s in namespace %s: %v", podName, podNamespace, err)), nil
+	}
+	ret += fmt.Sprintf("# The Pod %s in namespace %s has the following status (phase: %s)\n%s", podName, podNamespace, pod.Status.Phase, marshalledStatus)
+	if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+		return NewTextResult(ret, nil), nil
+	}
+	logs, err := derived.PodsLog(ctx, podNamespace, podName, kubernetes.PodsLogOptions{})
+	if err != nil {
+		return NewTextResult(ret, fmt.Errorf("failed to get pod log for pod %s in namespace %s: %v", podName, podNamespace, err)), nil
+	}
+	if logs == "" {
+		return NewTextResult(ret+"# The Pod has not produced any logs\n", nil), nil
+	}
+	return NewTextResult(ret+"# The Pod produced the following logs\n"+logs, nil), nil
+}
+
+func parsePodsRunOptions(arguments map[string]interface{}) (kubernetes.PodsRunOptions, error) {
+	podsRunOptions := kubernetes.PodsRunOptions{}
+	if v, ok := arguments["port"].(float64); ok {
+		podsRunOptions.Port = int32(v)
+	}
+	podsRunOptions.Command = stringSlice(arguments["command"])
+	podsRunOptions.Args = stringSlice(arguments["args"])
+	podsRunOptions.Env = stringMap(arguments["env"])
+	podsRunOptions.Labels = stringMap(arguments["labels"])
+	var err error
+	if podsRunOptions.Resources.Requests, err = resourceList(arguments["requests"]); err != nil {
+		return podsRunOptions, fmt.Errorf("invalid requests: %v", err)
+	}
+	if podsRunOptions.Resources.Limits, err = resourceList(arguments["limits"]); err != nil {
+		return podsRunOptions, fmt.Errorf("invalid limits: %v", err)
+	}
+	if v, ok := arguments["restartPolicy"].(string); ok {
+		podsRunOptions.RestartPolicy = v1.RestartPolicy(v)
+	}
+	if v, ok := arguments["serviceAccountName"].(string); ok {
+		podsRunOptions.ServiceAccountName = v
+	}
+	if v, ok := arguments["imagePullPolicy"].(string); ok {
+		podsRunOptions.ImagePullPolicy = v1.PullPolicy(v)
+	}
+	return podsRunOptions, nil
+}
+
+// stringItems ensures that the items property of a string array gets initialized
+// TODO: manual fix to ensure that the items property gets initialized (Gemini)
+// https://www.googlecloudcommunity.com/gc/AI-ML/Gemini-API-400-Bad-Request-Array-fields-breaks-function-calling/m-p/769835?nobounce
+func stringItems(schema map[string]interface{}) {
+	schema["type"] = "array"
+	schema["items"] = map[string]interface{}{
+		"type": "string",
+	}
+}
+
+func stringSlice(arg interface{}) []string {
+	var ret []string
+	if items, ok := arg.([]interface{}); ok {
+		for _, item := range items {
+			if s, ok := item.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+	}
+	return ret
+}
+
+func stringMap(arg interface{}) map[string]string {
+	var ret map[string]string
+	if entries, ok := arg.(map[string]interface{}); ok {
+		ret = make(map[string]string, len(entries))
+		for key, value := range entries {
+			// JSON numbers are decoded as float64, format them without exponent (e.g. 1000000 instead of 1e+06)
+			switch v := value.(type) {
+			case string:
+				ret[key] = v
+			case float64:
+				ret[key] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				ret[key] = strconv.FormatBool(v)
+			default:
+				ret[key] = fmt.Sprint(v)
+			}
+		}
+	}
+	return ret
+}
+
+func resourceList(arg interface{}) (v1.ResourceList, error) {
+	var ret v1.ResourceList
+	for name, value := range stringMap(arg) {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		if ret == nil {
+			ret = v1.ResourceList{}
+		}
+		ret[v1.ResourceName(name)] = quantity
+	}
+	return ret, nil
+}
+
+func parsePodsLogFollowOptions(arguments map[string]interface{}) (kubernetes.PodsLogFollowOptions, error) {
+	podsLogFollowOptions := kubernetes.PodsLogFollowOptions{}
+	if v, ok := arguments["followDuration"].(float64); ok {
+		if v <= 0 || v > podsLogMaxFollowDuration {
+			return podsLogFollowOptions, fmt.Errorf("followDuration must be between 1 and %d seconds", podsLogMaxFollowDuration)
+		}
+		podsLogFollowOptions.Duration = time.Duration(v * float64(time.Second))
+	}
+	if v, ok := arguments["followMaxLines"].(float64); ok {
+		if v <= 0 {
+			return podsLogFollowOptions, errors.New("followMaxLines must be greater than 0")
+		}
+		podsLogFollowOptions.MaxLines = int(v)
+	}
+	return podsLogFollowOptions, nil
+}
+
+// podsLogNotifier returns a function that sends every followed log line to the client as a progress notification
+// if the request provides a progress token, or as a logging notification otherwise
+func podsLogNotifier(ctx context.Context, ctr mcp.CallToolRequest, logger string) func(line string) {
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return nil
+	}
+	var progressToken mcp.ProgressToken
+	if ctr.Params.Meta != nil {
+		progressToken = ctr.Params.Meta.ProgressToken
+	}
+	progress := 0
+	return func(line string) {
+		progress++
+		if progressToken != nil {
+			_ = mcpServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+				"progressToken": progressToken,
+				"progress":      progress,
+				"message":       line,
+			})
+			return
+		}
+		_ = mcpServer.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(mcp.LoggingLevelInfo, logger, line))
+	}
+}
+
+func parsePodsLogOptions(arguments map[string]interface{}) (kubernetes.PodsLogOptions, error) {
+	podsLogOptions := kubernetes.PodsLogOptions{}
+	if v, ok := arguments["container"].(string); ok {
+		podsLogOptions.Container = v
+	}
+	if v, ok := arguments["previous"].(bool); ok {
+		podsLogOptions.Previous = v
+	}
+	if v, ok := arguments["tail"].(float64); ok {
+		podsLogOptions.TailLines = ptr.To(int64(v))
+	}
+	if v, ok := arguments["sinceSeconds"].(float64); ok {
+		if v <= 0 {
+			return podsLogOptions, errors.New("sinceSeconds must be greater than 0")
+		}
+		podsLogOptions.SinceSeconds = ptr.To(int64(v))
+	}
+	if v, ok := arguments["sinceTime"].(string); ok && v != "" {
+		if podsLogOptions.SinceSeconds != nil {
+			return podsLogOptions, errors.New("only one of sinceSeconds or sinceTime may be provided")
+		}
+		sinceTime, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return podsLogOptions, fmt.Errorf("invalid sinceTime %s, expected RFC3339 format", v)
+		}
+		podsLogOptions.SinceTime = &metav1.Time{Time: sinceTime}
+	}
+	if v, ok := arguments["timestamps"].(bool); ok {
+		podsLogOptions.Timestamps = v
+	}
+	if v, ok := arguments["limitBytes"].(float64); ok {
+		if v <= 0 {
+			return podsLogOptions, errors.New("limitBytes must be greater than 0")
+		}
+		podsLogOptions.LimitBytes = ptr.To(int64(v))
+	}
+	return podsLogOptions, nil
 }
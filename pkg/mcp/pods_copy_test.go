@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodsCopy(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var copiedToPod bytes.Buffer
+		var copiedToPodCommand string
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-copy/exec" {
+				return
+			}
+			streamOptions := &test.StreamOptions{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+			if req.URL.Query().Get("stdin") == "true" {
+				streamOptions.Stdin = &bytes.Buffer{}
+			}
+			ctx, err := test.CreateHTTPStreams(w, req, streamOptions)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+			defer func(conn io.Closer) { _ = conn.Close() }(ctx.Closer)
+			command := strings.Join(req.URL.Query()["command"], " ")
+			switch command {
+			case "tar cf - -C /etc/ app":
+				writer := tar.NewWriter(ctx.StdoutStream)
+				_ = writer.WriteHeader(&tar.Header{Name: "app/", Typeflag: tar.TypeDir, Mode: 0755})
+				_ = writer.WriteHeader(&tar.Header{Name: "app/config.yaml", Mode: 0644, Size: 11})
+				_, _ = writer.Write([]byte("key: value\n"))
+				_ = writer.WriteHeader(&tar.Header{Name: "app/heap.bin", Mode: 0644, Size: 4})
+				_, _ = writer.Write([]byte{0x00, 0x01, 0x02, 0x03})
+				_ = writer.Close()
+			case "tar xmf - -C /tmp/":
+				copiedToPodCommand = command
+				reader := tar.NewReader(ctx.StdinStream)
+				if header, err := reader.Next(); err == nil && header.Name == "uploaded.txt" {
+					_, _ = io.Copy(&copiedToPod, reader)
+				}
+			}
+		}))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-copy" {
+				return
+			}
+			test.WriteObject(w, &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-to-copy"},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "container-to-copy"}}},
+			})
+		}))
+		t.Run("pods_copy_from with relative path returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_copy_from", map[string]interface{}{"name": "pod-to-copy", "path": "etc/app"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to copy etc/app from pod pod-to-copy in namespace default: path etc/app must be an absolute file or directory path" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		podsCopyFrom, err := c.callTool("pods_copy_from", map[string]interface{}{"name": "pod-to-copy", "path": "/etc/app"})
+		t.Run("pods_copy_from returns the copied files", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if podsCopyFrom.IsError {
+				t.Fatalf("call tool failed %v", podsCopyFrom.Content[0].(mcp.TextContent).Text)
+			}
+			if len(podsCopyFrom.Content) != 2 {
+				t.Fatalf("expected 2 files, got %d", len(podsCopyFrom.Content))
+			}
+		})
+		t.Run("pods_copy_from returns text files as text", func(t *testing.T) {
+			text, ok := podsCopyFrom.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("expected text content, got %T", podsCopyFrom.Content[0])
+			}
+			if text.Text != "# /etc/app/config.yaml (11 bytes)\nkey: value\n" {
+				t.Errorf("unexpected text content %q", text.Text)
+			}
+		})
+		t.Run("pods_copy_from returns binary files as embedded resources", func(t *testing.T) {
+			resource, ok := podsCopyFrom.Content[1].(mcp.EmbeddedResource)
+			if !ok {
+				t.Fatalf("expected embedded resource, got %T", podsCopyFrom.Content[1])
+			}
+			blob, ok := resource.Resource.(mcp.BlobResourceContents)
+			if !ok {
+				t.Fatalf("expected blob resource, got %T", resource.Resource)
+			}
+			if blob.URI != "k8s://namespaces/default/pods/pod-to-copy/etc/app/heap.bin" {
+				t.Errorf("unexpected uri %s", blob.URI)
+			}
+			if blob.Blob != base64.StdEncoding.EncodeToString([]byte{0x00, 0x01, 0x02, 0x03}) {
+				t.Errorf("unexpected blob %s", blob.Blob)
+			}
+		})
+		t.Run("pods_copy_from exceeding max size returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_copy_from", map[string]interface{}{"name": "pod-to-copy", "path": "/etc/app", "maxSize": 512})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "content exceeds the maximum allowed size (512 bytes)") {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_copy_to copies base64 content into the container", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_copy_to", map[string]interface{}{
+				"name":     "pod-to-copy",
+				"path":     "/tmp/uploaded.txt",
+				"content":  base64.StdEncoding.EncodeToString([]byte("uploaded content")),
+				"encoding": "base64",
+			})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "16 bytes copied to /tmp/uploaded.txt in pod pod-to-copy in namespace default" {
+				t.Errorf("unexpected result %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if copiedToPodCommand != "tar xmf - -C /tmp/" {
+				t.Errorf("unexpected command %s", copiedToPodCommand)
+			}
+			if copiedToPod.String() != "uploaded content" {
+				t.Errorf("unexpected copied content %s", copiedToPod.String())
+			}
+		})
+	})
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestPodsDebug(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var debugContainer v1.EphemeralContainer
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v1/namespaces/default/pods/distroless":
+				test.WriteObject(w, &v1.Pod{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "distroless"},
+					Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+					Status:     v1.PodStatus{Phase: v1.PodRunning},
+				})
+			case req.Method == http.MethodPut && req.URL.Path == "/api/v1/namespaces/default/pods/distroless/ephemeralcontainers":
+				body, _ := io.ReadAll(req.Body)
+				obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				pod := obj.(*v1.Pod)
+				debugContainer = pod.Spec.EphemeralContainers[len(pod.Spec.EphemeralContainers)-1]
+				test.WriteObject(w, pod)
+			case req.URL.Path == "/api/v1/namespaces/default/pods" && req.URL.Query().Get("watch") != "true":
+				test.WriteObject(w, &v1.PodList{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+					ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+					Items: []v1.Pod{{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "distroless", ResourceVersion: "1"},
+						Status: v1.PodStatus{Phase: v1.PodRunning, EphemeralContainerStatuses: []v1.ContainerStatus{{
+							Name:  debugContainer.Name,
+							State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0}},
+						}}},
+					}},
+				})
+			case req.URL.Path == "/api/v1/namespaces/default/pods/distroless/log" && req.URL.Query().Get("container") == debugContainer.Name:
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte("PID   USER     COMMAND\n    1 root     /app\n"))
+			}
+		}))
+		t.Run("pods_debug with missing command returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_debug", map[string]interface{}{"name": "distroless", "image": "busybox"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to debug pod, missing argument command" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_debug with unknown target returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_debug", map[string]interface{}{
+				"namespace": "default", "name": "distroless", "image": "busybox", "command": []interface{}{"ps"}, "target": "sidecar",
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to debug pod distroless in namespace default: target container sidecar not found in pod distroless" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		podsDebug, err := c.callTool("pods_debug", map[string]interface{}{
+			"namespace": "default",
+			"name":      "distroless",
+			"image":     "busybox",
+			"command":   []interface{}{"ps", "aux"},
+			"target":    "app",
+		})
+		t.Run("pods_debug returns command output", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if podsDebug.IsError {
+				t.Fatalf("call tool failed %v", podsDebug.Content[0].(mcp.TextContent).Text)
+			}
+			text := podsDebug.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "# The command in debug container "+debugContainer.Name+" of pod distroless in namespace default exited with code 0\n") {
+				t.Errorf("unexpected header, got %v", text)
+			}
+			if !strings.Contains(text, "1 root     /app") {
+				t.Errorf("expected command output, got %v", text)
+			}
+		})
+		t.Run("pods_debug adds ephemeral container targeting the provided container", func(t *testing.T) {
+			if !strings.HasPrefix(debugContainer.Name, "debugger-") {
+				t.Errorf("unexpected debug container name %s", debugContainer.Name)
+			}
+			if debugContainer.Image != "busybox" || debugContainer.TargetContainerName != "app" {
+				t.Errorf("unexpected debug container %v", debugContainer)
+			}
+			if strings.Join(debugContainer.Command, " ") != "ps aux" {
+				t.Errorf("unexpected debug container command %v", debugContainer.Command)
+			}
+		})
+	})
+}
@@ -0,0 +1,197 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestPodsDeleteBulk(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var mutex sync.Mutex
+		var deleted, evicted []string
+		var gracePeriods []int64
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list", "delete"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if req.URL.Path == "/api/v1/namespaces/default/pods" && req.Method == http.MethodGet {
+				if req.URL.Query().Get("labelSelector") != "app=stuck" {
+					test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}})
+					return
+				}
+				test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: []v1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "stuck-1", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-1"}, Status: v1.PodStatus{Phase: v1.PodPending}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "stuck-2", Namespace: "default"}, Spec: v1.PodSpec{NodeName: "node-2"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+				}})
+				return
+			}
+			if req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/default/pods/") {
+				body, _ := io.ReadAll(req.Body)
+				deleteOptions := &metav1.DeleteOptions{}
+				_, _, _ = scheme.Codecs.UniversalDeserializer().Decode(body, nil, deleteOptions)
+				mutex.Lock()
+				deleted = append(deleted, strings.TrimPrefix(req.URL.Path, "/api/v1/namespaces/default/pods/"))
+				if deleteOptions.GracePeriodSeconds != nil {
+					gracePeriods = append(gracePeriods, *deleteOptions.GracePeriodSeconds)
+				}
+				mutex.Unlock()
+				test.WriteObject(w, &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusSuccess})
+				return
+			}
+			if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/eviction") {
+				body, _ := io.ReadAll(req.Body)
+				obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				eviction := obj.(*policyv1.Eviction)
+				if eviction.Name == "stuck-2" {
+					w.WriteHeader(http.StatusTooManyRequests)
+					test.WriteObject(w, &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusFailure,
+						Code: http.StatusTooManyRequests, Reason: metav1.StatusReasonTooManyRequests,
+						Message: "Cannot evict pod as it would violate the pod's disruption budget."})
+					return
+				}
+				mutex.Lock()
+				evicted = append(evicted, eviction.Name)
+				mutex.Unlock()
+				test.WriteObject(w, &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusSuccess})
+				return
+			}
+		}))
+		t.Run("pods_delete with name and labelSelector returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_delete", map[string]interface{}{"name": "stuck-1", "labelSelector": "app=stuck"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to delete pod, name can't be combined with labelSelector" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_delete with force and evict returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_delete", map[string]interface{}{"labelSelector": "app=stuck", "force": true, "evict": true})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "force can't be combined with evict") {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_delete with dryRun lists affected pods", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_delete", map[string]interface{}{"labelSelector": "app=stuck", "dryRun": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The following 2 Pods in namespace default would be deleted (dry run)\n" +
+				"- stuck-1 (phase: Pending, node: node-1)\n" +
+				"- stuck-2 (phase: Running, node: node-2)\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if len(deleted) != 0 || len(evicted) != 0 {
+				t.Errorf("dry run should not delete pods, deleted %v, evicted %v", deleted, evicted)
+			}
+		})
+		t.Run("pods_delete with labelSelector and force deletes all matching pods immediately", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_delete", map[string]interface{}{"labelSelector": "app=stuck", "force": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "# 2 Pods deleted in namespace default\n- stuck-1\n- stuck-2\n" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			sort.Strings(deleted)
+			if strings.Join(deleted, ",") != "stuck-1,stuck-2" {
+				t.Errorf("unexpected deleted pods %v", deleted)
+			}
+			if len(gracePeriods) != 2 || gracePeriods[0] != 0 || gracePeriods[1] != 0 {
+				t.Errorf("expected grace period of 0, got %v", gracePeriods)
+			}
+		})
+		t.Run("pods_delete with labelSelector and evict reports PodDisruptionBudget violations", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_delete", map[string]interface{}{"labelSelector": "app=stuck", "evict": true})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "# 1 Pods evicted in namespace default\n- stuck-1\n# 1 Pods failed to be evicted\n- stuck-2: blocked by PodDisruptionBudget: ") {
+				t.Errorf("unexpected result, got %v", text)
+			}
+			if len(evicted) != 1 || evicted[0] != "stuck-1" {
+				t.Errorf("unexpected evicted pods %v", evicted)
+			}
+		})
+		t.Run("pods_delete with labelSelector matching no pods returns message", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_delete", map[string]interface{}{"labelSelector": "app=nothing"})
+			if toolResult.Content[0].(mcp.TextContent).Text != "No pods found matching label selector app=nothing in namespace default" {
+				t.Errorf("unexpected message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestPodsDeleteManagedServiceDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Service"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		deleted := false
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list", "delete"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if req.URL.Path == "/api/v1/namespaces/default/pods/a-managed-pod" && req.Method == http.MethodGet {
+				test.WriteObject(w, &v1.Pod{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{
+					Name: "a-managed-pod", Namespace: "default",
+					Labels: map[string]string{"app.kubernetes.io/managed-by": "kubernetes-mcp-server", "app.kubernetes.io/name": "a-managed-pod"},
+				}})
+				return
+			}
+			if req.Method == http.MethodDelete {
+				deleted = true
+				test.WriteObject(w, &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusSuccess})
+				return
+			}
+		}))
+		toolResult, _ := c.callTool("pods_delete", map[string]interface{}{"name": "a-managed-pod"})
+		t.Run("pods_delete of managed pod has error", func(t *testing.T) {
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("pods_delete of managed pod describes Service denial", func(t *testing.T) {
+			expectedMessage := "failed to delete pod a-managed-pod in namespace : resource not allowed: /v1, Kind=Service"
+			if toolResult.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_delete of managed pod doesn't delete the pod", func(t *testing.T) {
+			if deleted {
+				t.Errorf("pod should not be deleted when its Service can't be deleted")
+			}
+		})
+	})
+}
@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func TestPodsDiagnose(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+				{Name: "events", Namespaced: true, Kind: "Event", Verbs: []string{"get", "list"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/api/v1/namespaces/default/pods/unhealthy":
+				test.WriteObject(w, &v1.Pod{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unhealthy", UID: "uid-1"},
+					Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{
+						{
+							Name:      "app",
+							Image:     "app:latest",
+							Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")}},
+							ReadinessProbe: &v1.Probe{
+								ProbeHandler:  v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt32(8080), Scheme: v1.URISchemeHTTP}},
+								PeriodSeconds: 10,
+							},
+						},
+						{Name: "sidecar", Image: "sidecar:latest"},
+					}},
+					Status: v1.PodStatus{
+						Phase:      v1.PodRunning,
+						Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse, Reason: "ContainersNotReady"}},
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name:                 "app",
+								RestartCount:         5,
+								State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"}},
+								LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+							},
+							{
+								Name:  "sidecar",
+								State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CreateContainerConfigError", Message: `configmap "sidecar-config" not found`}},
+							},
+						},
+					},
+				})
+			case "/api/v1/namespaces/default/events":
+				if req.URL.Query().Get("fieldSelector") != "involvedObject.kind=Pod,involvedObject.name=unhealthy" {
+					t.Errorf("unexpected field selector %s", req.URL.Query().Get("fieldSelector"))
+				}
+				test.WriteObject(w, &v1.EventList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EventList"}, Items: []v1.Event{
+					{
+						TypeMeta:       metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+						ObjectMeta:     metav1.ObjectMeta{Name: "event-1", Namespace: "default"},
+						InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "unhealthy", UID: "uid-1"},
+						Type:           v1.EventTypeWarning,
+						Reason:         "Unhealthy",
+						Message:        "Readiness probe failed: HTTP probe failed with statuscode: 503",
+					},
+					{
+						TypeMeta:       metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+						ObjectMeta:     metav1.ObjectMeta{Name: "event-2", Namespace: "default"},
+						InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "unhealthy", UID: "uid-of-a-previous-pod"},
+						Type:           v1.EventTypeWarning,
+						Reason:         "FailedScheduling",
+						Message:        "event of a previous pod with the same name",
+					},
+				}})
+			case "/api/v1/namespaces/default/pods/unhealthy/log":
+				w.Header().Set("Content-Type", "text/plain")
+				if req.URL.Query().Get("previous") == "true" && req.URL.Query().Get("container") == "app" && req.URL.Query().Get("tailLines") == "20" {
+					_, _ = w.Write([]byte("allocating buffers\n"))
+				}
+			}
+		}))
+		t.Run("pods_diagnose with missing name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_diagnose", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to diagnose pod, missing argument name" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		podsDiagnose, err := c.callTool("pods_diagnose", map[string]interface{}{"namespace": "default", "name": "unhealthy"})
+		t.Run("pods_diagnose returns probable causes", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if podsDiagnose.IsError {
+				t.Fatalf("call tool failed %v", podsDiagnose.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.HasPrefix(podsDiagnose.Content[0].(mcp.TextContent).Text, "# Found 4 probable causes for the problems of pod unhealthy in namespace default\n") {
+				t.Errorf("unexpected header, got %v", podsDiagnose.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		var diagnosis kubernetes.PodsDiagnosis
+		err = yaml.Unmarshal([]byte(podsDiagnose.Content[0].(mcp.TextContent).Text), &diagnosis)
+		t.Run("pods_diagnose has yaml content", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("invalid tool result content %v", err)
+			}
+		})
+		t.Run("pods_diagnose detects the causes", func(t *testing.T) {
+			expectedCauses := []string{
+				"CrashLoopBackOff: container app keeps crashing (5 restarts), last terminated with reason OOMKilled and exit code 137",
+				"OOMKilled: container app was killed because it ran out of memory (memory limit: 64Mi)",
+				`CreateContainerConfigError: container sidecar can't be created, check that the referenced ConfigMaps and Secrets exist: configmap "sidecar-config" not found`,
+				"ProbeFailure: Readiness probe failed: HTTP probe failed with statuscode: 503",
+			}
+			for _, expected := range expectedCauses {
+				found := false
+				for _, cause := range diagnosis.ProbableCauses {
+					found = found || strings.HasPrefix(cause, expected)
+				}
+				if !found {
+					t.Errorf("expected cause %q, got %v", expected, diagnosis.ProbableCauses)
+				}
+			}
+		})
+		t.Run("pods_diagnose returns container details", func(t *testing.T) {
+			if len(diagnosis.Containers) != 2 {
+				t.Fatalf("expected 2 containers, got %d", len(diagnosis.Containers))
+			}
+			if diagnosis.Containers[0].RestartCount != 5 || diagnosis.Containers[0].LastTermination.Reason != "OOMKilled" {
+				t.Errorf("unexpected container %v", diagnosis.Containers[0])
+			}
+			if diagnosis.Containers[0].ReadinessProbe != "http-get http://:8080/ready delay=0s timeout=0s period=10s #success=0 #failure=0" {
+				t.Errorf("unexpected readiness probe %s", diagnosis.Containers[0].ReadinessProbe)
+			}
+		})
+		t.Run("pods_diagnose only returns events of the current pod", func(t *testing.T) {
+			if len(diagnosis.Events) != 1 || diagnosis.Events[0].Reason != "Unhealthy" {
+				t.Errorf("unexpected events %v", diagnosis.Events)
+			}
+		})
+		t.Run("pods_diagnose returns previous logs of restarted containers", func(t *testing.T) {
+			if len(diagnosis.PreviousLogs) != 1 || diagnosis.PreviousLogs[0].Container != "app" || diagnosis.PreviousLogs[0].Log != "allocating buffers\n" {
+				t.Errorf("unexpected previous logs %v", diagnosis.PreviousLogs)
+			}
+		})
+	})
+}
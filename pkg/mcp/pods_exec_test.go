@@ -11,6 +11,7 @@ import (
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/mark3labs/mcp-go/mcp"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -126,3 +127,103 @@ func TestPodsExecDenied(t *testing.T) {
 		})
 	})
 }
+
+func TestPodsExecResult(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-exec/exec" {
+				return
+			}
+			streamOptions := &test.StreamOptions{Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+			if req.URL.Query().Get("stdin") == "true" {
+				streamOptions.Stdin = &bytes.Buffer{}
+			}
+			ctx, err := test.CreateHTTPStreams(w, req, streamOptions)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+			defer func(conn io.Closer) { _ = conn.Close() }(ctx.Closer)
+			switch strings.Join(req.URL.Query()["command"], " ") {
+			case "cat":
+				in, _ := io.ReadAll(ctx.StdinStream)
+				_, _ = ctx.StdoutStream.Write(in)
+			case "fail":
+				_, _ = io.WriteString(ctx.StdoutStream, "some output\n")
+				_, _ = io.WriteString(ctx.StderrStream, "something failed\n")
+				_ = ctx.WriteStatus(&apierrors.StatusError{ErrStatus: metav1.Status{
+					Status: metav1.StatusFailure,
+					Reason: "NonZeroExitCode",
+					Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{
+						{Type: "ExitCode", Message: "42"},
+					}},
+				}})
+			}
+		}))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-exec" {
+				return
+			}
+			test.WriteObject(w, &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "pod-to-exec",
+				},
+				Spec: v1.PodSpec{Containers: []v1.Container{{Name: "container-to-exec"}}},
+			})
+		}))
+		t.Run("pods_exec with stdin sends content to the command", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_exec", map[string]interface{}{
+				"name":    "pod-to-exec",
+				"command": []interface{}{"cat"},
+				"stdin":   "hello from stdin",
+			})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "exited with code 0") {
+				t.Errorf("expected exit code 0, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "stdout: hello from stdin\n") {
+				t.Errorf("expected stdin echoed in stdout, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_exec with failing command returns exit code, stdout and stderr", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_exec", map[string]interface{}{
+				"name":    "pod-to-exec",
+				"command": []interface{}{"fail"},
+			})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail for non-zero exit code")
+			}
+			for _, expected := range []string{"exited with code 42", "exitCode: 42", "stdout: |\n  some output\n", "stderr: |\n  something failed\n"} {
+				if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, expected) {
+					t.Errorf("expected %q, got %v", expected, toolResult.Content[0].(mcp.TextContent).Text)
+				}
+			}
+		})
+		t.Run("pods_exec with invalid timeout returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_exec", map[string]interface{}{
+				"name":    "pod-to-exec",
+				"command": []interface{}{"cat"},
+				"timeout": -1,
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to exec in pod, timeout must be greater than 0" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
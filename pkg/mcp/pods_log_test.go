@@ -0,0 +1,219 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestPodsLogOptions(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-with-logs/log" {
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			// Echo the query so that the test can verify the log options
+			_, _ = w.Write([]byte("query:" + req.URL.RawQuery + "\n"))
+		}))
+		t.Run("pods_log with defaults tails 256 lines", func(t *testing.T) {
+			toolResult, err := c.callTool("pods_log", map[string]interface{}{
+				"name": "pod-with-logs",
+			})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "tailLines=256") {
+				t.Errorf("expected default tailLines, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with tail -1 retrieves all lines", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{
+				"name": "pod-with-logs",
+				"tail": -1,
+			})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "tailLines") {
+				t.Errorf("expected no tailLines, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with options passes them to the API", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{
+				"name":         "pod-with-logs",
+				"container":    "a-container",
+				"previous":     true,
+				"tail":         10,
+				"sinceSeconds": 3600,
+				"timestamps":   true,
+				"limitBytes":   1024,
+			})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			for _, expected := range []string{"container=a-container", "previous=true", "tailLines=10", "sinceSeconds=3600", "timestamps=true", "limitBytes=1024"} {
+				if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, expected) {
+					t.Errorf("expected %s, got %v", expected, toolResult.Content[0].(mcp.TextContent).Text)
+				}
+			}
+		})
+		t.Run("pods_log with sinceTime passes it to the API", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{
+				"name":      "pod-with-logs",
+				"sinceTime": "2025-01-01T10:00:00Z",
+			})
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "sinceTime=2025-01-01T10%3A00%3A00Z") {
+				t.Errorf("expected sinceTime, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with invalid sinceTime returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{
+				"name":      "pod-with-logs",
+				"sinceTime": "yesterday",
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get pod log, invalid sinceTime yesterday, expected RFC3339 format" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with sinceSeconds and sinceTime returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{
+				"name":         "pod-with-logs",
+				"sinceSeconds": 60,
+				"sinceTime":    "2025-01-01T10:00:00Z",
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get pod log, only one of sinceSeconds or sinceTime may be provided" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestPodsLogFollow(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/migration/log" || req.URL.Query().Get("follow") != "true" {
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			for _, line := range []string{"migrating 1/3", "migrating 2/3", "migrating 3/3", "migration completed"} {
+				_, _ = w.Write([]byte(line + "\n"))
+				w.(http.Flusher).Flush()
+			}
+			// Keep the stream open as a running container would
+			<-req.Context().Done()
+		}))
+		var mutex sync.Mutex
+		var notifications []mcp.JSONRPCNotification
+		c.mcpClient.OnNotification(func(n mcp.JSONRPCNotification) {
+			if n.Method != "notifications/progress" && n.Method != "notifications/message" {
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			notifications = append(notifications, n)
+		})
+		waitForNotifications := func(count int) []mcp.JSONRPCNotification {
+			for i := 0; i < 50; i++ {
+				mutex.Lock()
+				if len(notifications) >= count {
+					received := notifications
+					notifications = nil
+					mutex.Unlock()
+					return received
+				}
+				mutex.Unlock()
+				time.Sleep(100 * time.Millisecond)
+			}
+			return nil
+		}
+		t.Run("pods_log with invalid followDuration returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_log", map[string]interface{}{"name": "migration", "follow": true, "followDuration": 3600})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get pod log, followDuration must be between 1 and 300 seconds" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_log with follow and followMaxLines sends progress notifications", func(t *testing.T) {
+			callToolRequest := mcp.CallToolRequest{}
+			callToolRequest.Params.Name = "pods_log"
+			callToolRequest.Params.Arguments = map[string]interface{}{"namespace": "default", "name": "migration", "follow": true, "followMaxLines": 3}
+			callToolRequest.Params.Meta = &mcp.Meta{ProgressToken: "follow-migration"}
+			toolResult, err := c.mcpClient.CallTool(c.ctx, callToolRequest)
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "migrating 1/3\nmigrating 2/3\nmigrating 3/3\n" {
+				t.Errorf("unexpected logs, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			received := waitForNotifications(3)
+			if len(received) != 3 {
+				t.Fatalf("expected 3 notifications, got %v", received)
+			}
+			for i, n := range received {
+				if n.Method != "notifications/progress" {
+					t.Errorf("expected progress notification, got %s", n.Method)
+				}
+				if n.Params.AdditionalFields["progressToken"] != "follow-migration" || n.Params.AdditionalFields["progress"] != float64(i+1) {
+					t.Errorf("unexpected progress notification %v", n.Params.AdditionalFields)
+				}
+			}
+			if received[2].Params.AdditionalFields["message"] != "migrating 3/3" {
+				t.Errorf("unexpected progress message %v", received[2].Params.AdditionalFields["message"])
+			}
+		})
+		t.Run("pods_log with follow and followDuration sends logging notifications", func(t *testing.T) {
+			if err := c.mcpClient.SetLevel(c.ctx, mcp.SetLevelRequest{Params: struct {
+				Level mcp.LoggingLevel `json:"level"`
+			}{Level: mcp.LoggingLevelInfo}}); err != nil {
+				t.Fatalf("set level failed %v", err)
+			}
+			start := time.Now()
+			toolResult, err := c.callTool("pods_log", map[string]interface{}{"name": "migration", "follow": true, "followDuration": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult)
+			}
+			if time.Since(start) < time.Second {
+				t.Errorf("expected logs to be followed for 1 second, took %s", time.Since(start))
+			}
+			if !strings.HasSuffix(toolResult.Content[0].(mcp.TextContent).Text, "migration completed\n") {
+				t.Errorf("unexpected logs, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			received := waitForNotifications(4)
+			if len(received) != 4 {
+				t.Fatalf("expected 4 notifications, got %v", received)
+			}
+			if received[3].Method != "notifications/message" || received[3].Params.AdditionalFields["data"] != "migration completed" {
+				t.Errorf("unexpected logging notification %v", received[3])
+			}
+			if received[3].Params.AdditionalFields["logger"] != "pods/default/migration" {
+				t.Errorf("expected logger with the resolved namespace, got %v", received[3].Params.AdditionalFields["logger"])
+			}
+		})
+	})
+}
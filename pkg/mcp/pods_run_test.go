@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodsRunWithContainerSpec(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var applied v1.Pod
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list", "watch", "create", "patch"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			// Server-side apply of the Pod, echo the applied object
+			if req.Method == http.MethodPatch && req.URL.Path == "/api/v1/namespaces/default/pods/job-pod" {
+				body, _ := io.ReadAll(req.Body)
+				_ = json.Unmarshal(body, &applied)
+				_, _ = w.Write(body)
+				return
+			}
+			if req.URL.Path == "/api/v1/namespaces/default/pods" && req.URL.Query().Get("watch") != "true" {
+				test.WriteObject(w, &v1.PodList{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+					ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+					Items: []v1.Pod{{
+						ObjectMeta: metav1.ObjectMeta{Name: "job-pod", Namespace: "default", ResourceVersion: "1"},
+						Status:     v1.PodStatus{Phase: v1.PodSucceeded},
+					}},
+				})
+				return
+			}
+			if req.URL.Path == "/api/v1/namespaces/default/pods/job-pod/log" {
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte("job completed\n"))
+				return
+			}
+		}))
+		podsRun, err := c.callTool("pods_run", map[string]interface{}{
+			"namespace":          "default",
+			"name":               "job-pod",
+			"image":              "busybox",
+			"command":            []interface{}{"sh", "-c"},
+			"args":               []interface{}{"echo job completed"},
+			"env":                map[string]interface{}{"B_VAR": "b", "A_VAR": "a", "C_VAR": 1000000, "D_VAR": true},
+			"requests":           map[string]interface{}{"cpu": "100m", "memory": "64Mi"},
+			"limits":             map[string]interface{}{"memory": "128Mi"},
+			"restartPolicy":      "Never",
+			"serviceAccountName": "runner",
+			"labels":             map[string]interface{}{"team": "platform", "app.kubernetes.io/managed-by": "someone-else"},
+			"imagePullPolicy":    "IfNotPresent",
+			"wait":               true,
+		})
+		t.Run("pods_run with container spec and wait returns no error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if podsRun.IsError {
+				t.Fatalf("call tool failed %v", podsRun.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("pods_run applies the container spec", func(t *testing.T) {
+			if applied.Spec.RestartPolicy != v1.RestartPolicyNever {
+				t.Errorf("unexpected restartPolicy %s", applied.Spec.RestartPolicy)
+			}
+			if applied.Spec.ServiceAccountName != "runner" {
+				t.Errorf("unexpected serviceAccountName %s", applied.Spec.ServiceAccountName)
+			}
+			container := applied.Spec.Containers[0]
+			if strings.Join(container.Command, " ") != "sh -c" || strings.Join(container.Args, " ") != "echo job completed" {
+				t.Errorf("unexpected command %v and args %v", container.Command, container.Args)
+			}
+			if len(container.Env) != 4 || container.Env[0].Name != "A_VAR" || container.Env[1].Value != "b" ||
+				container.Env[2].Value != "1000000" || container.Env[3].Value != "true" {
+				t.Errorf("unexpected env %v", container.Env)
+			}
+			if container.Resources.Requests.Cpu().String() != "100m" || container.Resources.Limits.Memory().String() != "128Mi" {
+				t.Errorf("unexpected resources %v", container.Resources)
+			}
+			if container.ImagePullPolicy != v1.PullIfNotPresent {
+				t.Errorf("unexpected imagePullPolicy %s", container.ImagePullPolicy)
+			}
+		})
+		t.Run("pods_run keeps server-managed labels", func(t *testing.T) {
+			if applied.Labels["team"] != "platform" {
+				t.Errorf("expected team label, got %v", applied.Labels)
+			}
+			if applied.Labels["app.kubernetes.io/managed-by"] != "kubernetes-mcp-server" {
+				t.Errorf("expected managed-by label to be preserved, got %v", applied.Labels)
+			}
+		})
+		t.Run("pods_run with wait returns status and logs of completed pod", func(t *testing.T) {
+			text := podsRun.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "# The Pod job-pod in namespace default has the following status (phase: Succeeded)\n") {
+				t.Errorf("expected pod status, got %v", text)
+			}
+			if !strings.HasSuffix(text, "# The Pod produced the following logs\njob completed\n") {
+				t.Errorf("expected pod logs, got %v", text)
+			}
+		})
+		t.Run("pods_run with invalid resource quantity returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("pods_run", map[string]interface{}{
+				"image":    "busybox",
+				"requests": map[string]interface{}{"cpu": "lots"},
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to run pod, invalid requests: cpu: ") {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
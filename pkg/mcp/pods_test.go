@@ -436,13 +436,13 @@ func TestPodsDelete(t *testing.T) {
 	testCase(t, func(c *mcpContext) {
 		c.withEnvTest()
 		// Errors
-		t.Run("pods_delete with nil name returns error", func(t *testing.T) {
+		t.Run("pods_delete with nil name and nil labelSelector returns error", func(t *testing.T) {
 			toolResult, _ := c.callTool("pods_delete", map[string]interface{}{})
 			if toolResult.IsError != true {
 				t.Errorf("call tool should fail")
 				return
 			}
-			if toolResult.Content[0].(mcp.TextContent).Text != "failed to delete pod, missing argument name" {
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to delete pod, missing argument name or labelSelector" {
 				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
 				return
 			}
@@ -189,6 +189,23 @@ func TestPodsTopMetricsAvailable(t *testing.T) {
 				t.Errorf("Expected total row '%s' not found in output:\n%s", expectedTotal.String(), textContent)
 			}
 		})
+		podsTopWithoutContainers, err := c.callTool("pods_top", map[string]interface{}{
+			"containers": false,
+		})
+		t.Run("pods_top[containers=false] returns pod metrics totals per pod", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			textContent := podsTopWithoutContainers.Content[0].(mcp.TextContent).Text
+			expectedHeaders := regexp.MustCompile(`(?m)^\s*NAMESPACE\s+NAME\s+CPU\(cores\)\s+MEMORY\(bytes\)\s*$`)
+			if !expectedHeaders.MatchString(textContent) {
+				t.Errorf("Expected headers '%s' not found in output:\n%s", expectedHeaders.String(), textContent)
+			}
+			expectedRow := regexp.MustCompile(`default\s+pod-1\s+300m\s+500Mi`)
+			if !expectedRow.MatchString(textContent) {
+				t.Errorf("Expected row '%s' not found in output:\n%s", expectedRow.String(), textContent)
+			}
+		})
 		podsTopNamespaceLabelSelector, err := c.callTool("pods_top", map[string]interface{}{
 			"label_selector": "app=pod-ns-5-42",
 		})
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func (s *Server) initPortForward() []server.ServerTool {
+	return []server.ServerTool{
+		{Tool: mcp.NewTool("port_forward_start",
+			mcp.WithDescription("Start forwarding a local port to a Kubernetes Pod or Service in the current or provided namespace. "+
+				"The forwarded local port (bound to localhost) is returned and remains open until port_forward_stop is called"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the Pod or Service (Optional, current namespace if not provided)")),
+			mcp.WithString("kind", mcp.Description("Kind of the resource to forward the port to (Optional, defaults to Pod)"), mcp.Enum("Pod", "Service")),
+			mcp.WithString("name", mcp.Description("Name of the Pod or Service to forward the port to"), mcp.Required()),
+			mcp.WithNumber("remotePort", mcp.Description("Port of the Pod container, or port of the Service, to forward to"), mcp.Required()),
+			mcp.WithNumber("localPort", mcp.Description("Local port to listen on (Optional, a random available port if not provided)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Port Forward: Start"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.portForwardStart},
+		{Tool: mcp.NewTool("port_forward_list",
+			mcp.WithDescription("List the active port-forward sessions started by this server"),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Port Forward: List"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(false),
+		), Handler: s.portForwardList},
+		{Tool: mcp.NewTool("port_forward_stop",
+			mcp.WithDescription("Stop an active port-forward session started by this server"),
+			mcp.WithString("id", mcp.Description("ID of the port-forward session to stop"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Port Forward: Stop"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(false),
+		), Handler: s.portForwardStop},
+	}
+}
+
+func (s *Server) portForwardStart(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	portForwardOptions := kubernetes.PortForwardOptions{}
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		portForwardOptions.Namespace = v
+	}
+	if v, ok := ctr.GetArguments()["kind"].(string); ok {
+		portForwardOptions.Kind = v
+	}
+	if v, ok := ctr.GetArguments()["name"].(string); ok {
+		portForwardOptions.Name = v
+	} else {
+		return NewTextResult("", errors.New("failed to start port-forward, missing argument name")), nil
+	}
+	if v, ok := ctr.GetArguments()["remotePort"].(float64); ok {
+		portForwardOptions.RemotePort = int32(v)
+	} else {
+		return NewTextResult("", errors.New("failed to start port-forward, missing argument remotePort")), nil
+	}
+	if v, ok := ctr.GetArguments()["localPort"].(float64); ok {
+		portForwardOptions.LocalPort = int32(v)
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, err := derived.PortForwardStart(ctx, s.portForwards, portForwardOptions)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to start port-forward to %s in namespace %s: %v", portForwardOptions.Name, portForwardOptions.Namespace, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("Port-forward %s started, %s in namespace %s is now available at %s:%d",
+		session.ID, session.Target, session.Namespace, session.Address, session.LocalPort), nil), nil
+}
+
+func (s *Server) portForwardList(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sessions := s.portForwards.List()
+	if len(sessions) == 0 {
+		return NewTextResult("No active port-forward sessions found", nil), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(sessions)
+	if err != nil {
+		err = fmt.Errorf("failed to list port-forward sessions: %v", err)
+	}
+	return NewTextResult("The following port-forward sessions (YAML format) are active:\n"+marshalledYaml, err), nil
+}
+
+func (s *Server) portForwardStop(_ context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, ok := ctr.GetArguments()["id"].(string)
+	if !ok {
+		return NewTextResult("", errors.New("failed to stop port-forward, missing argument id")), nil
+	}
+	session, err := s.portForwards.Stop(id)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to stop port-forward: %v", err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("Port-forward %s to %s in namespace %s stopped", session.ID, session.Target, session.Namespace), nil), nil
+}
@@ -0,0 +1,142 @@
+package mcp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+)
+
+func TestPortForward(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/namespaces/default/pods/pod-to-forward/portforward" {
+				return
+			}
+			if _, err := httpstream.Handshake(req, w, []string{"portforward.k8s.io"}); err != nil {
+				return
+			}
+			// Echo server for every data stream
+			conn := spdy.NewResponseUpgrader().UpgradeResponse(w, req, func(stream httpstream.Stream, _ <-chan struct{}) error {
+				if stream.Headers().Get(v1.StreamType) == v1.StreamTypeData {
+					go func() {
+						_, _ = io.Copy(stream, stream)
+						_ = stream.Close()
+					}()
+				}
+				return nil
+			})
+			if conn != nil {
+				<-conn.CloseChan()
+			}
+		}))
+		portForwardStart, err := c.callTool("port_forward_start", map[string]interface{}{
+			"name":       "pod-to-forward",
+			"remotePort": 8080,
+		})
+		t.Run("port_forward_start returns local port", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if portForwardStart.IsError {
+				t.Fatalf("call tool failed %v", portForwardStart.Content[0].(mcp.TextContent).Text)
+			}
+			if !regexp.MustCompile(`^Port-forward pf-\w+ started, Pod/pod-to-forward in namespace default is now available at localhost:\d+$`).
+				MatchString(portForwardStart.Content[0].(mcp.TextContent).Text) {
+				t.Fatalf("unexpected result %v", portForwardStart.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		matches := regexp.MustCompile(`^Port-forward (pf-\w+) .* at (localhost:\d+)$`).FindStringSubmatch(portForwardStart.Content[0].(mcp.TextContent).Text)
+		if len(matches) != 3 {
+			t.Fatalf("unexpected port_forward_start result %v", portForwardStart.Content[0].(mcp.TextContent).Text)
+		}
+		id, address := matches[1], matches[2]
+		t.Run("port_forward_start forwards traffic to the Pod", func(t *testing.T) {
+			conn, err := net.Dial("tcp", address)
+			if err != nil {
+				t.Fatalf("failed to connect to forwarded port %v", err)
+			}
+			defer func() { _ = conn.Close() }()
+			_, _ = fmt.Fprintf(conn, "ping\n")
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			if err != nil {
+				t.Fatalf("failed to read from forwarded port %v", err)
+			}
+			if line != "ping\n" {
+				t.Fatalf("unexpected echo %q", line)
+			}
+		})
+		t.Run("port_forward_list returns active session", func(t *testing.T) {
+			portForwardList, err := c.callTool("port_forward_list", map[string]interface{}{})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			for _, expected := range []string{"id: " + id, "target: Pod/pod-to-forward", "remotePort: 8080", "namespace: default"} {
+				if !strings.Contains(portForwardList.Content[0].(mcp.TextContent).Text, expected) {
+					t.Errorf("expected %s, got %v", expected, portForwardList.Content[0].(mcp.TextContent).Text)
+				}
+			}
+		})
+		t.Run("port_forward_stop stops session", func(t *testing.T) {
+			portForwardStop, err := c.callTool("port_forward_stop", map[string]interface{}{"id": id})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if portForwardStop.IsError {
+				t.Fatalf("call tool failed %v", portForwardStop.Content[0].(mcp.TextContent).Text)
+			}
+			portForwardList, _ := c.callTool("port_forward_list", map[string]interface{}{})
+			if portForwardList.Content[0].(mcp.TextContent).Text != "No active port-forward sessions found" {
+				t.Errorf("expected no sessions, got %v", portForwardList.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("port_forward_stop with unknown id returns error", func(t *testing.T) {
+			portForwardStop, _ := c.callTool("port_forward_stop", map[string]interface{}{"id": "pf-unknown"})
+			if !portForwardStop.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if portForwardStop.Content[0].(mcp.TextContent).Text != "failed to stop port-forward: port-forward session pf-unknown not found" {
+				t.Errorf("invalid error message, got %v", portForwardStop.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestPortForwardDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Version: "v1", Kind: "Pod"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		portForwardStart, _ := c.callTool("port_forward_start", map[string]interface{}{
+			"namespace":  "default",
+			"name":       "pod-to-forward",
+			"remotePort": 8080,
+		})
+		t.Run("port_forward_start has error", func(t *testing.T) {
+			if !portForwardStart.IsError {
+				t.Fatalf("call tool should fail")
+			}
+		})
+		t.Run("port_forward_start describes denial", func(t *testing.T) {
+			expectedMessage := "failed to start port-forward to pod-to-forward in namespace default: resource not allowed: /v1, Kind=Pod"
+			if portForwardStart.Content[0].(mcp.TextContent).Text != expectedMessage {
+				t.Fatalf("expected descriptive error '%s', got %v", expectedMessage, portForwardStart.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
@@ -40,8 +40,12 @@ func (p *FullProfile) GetTools(s *Server) []server.ServerTool {
 		s.initConfiguration(),
 		s.initEvents(),
 		s.initNamespaces(),
+		s.initNodes(),
 		s.initPods(),
+		s.initPortForward(),
 		s.initResources(),
+		s.initWorkloads(),
+		s.initKustomize(),
 		s.initHelm(),
 	)
 }
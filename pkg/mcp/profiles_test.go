@@ -15,18 +15,44 @@ func TestFullProfileTools(t *testing.T) {
 		"helm_list",
 		"helm_uninstall",
 		"namespaces_list",
+		"nodes_top",
+		"nodes_cordon",
+		"nodes_uncordon",
+		"nodes_drain",
 		"pods_list",
 		"pods_list_in_namespace",
 		"pods_get",
 		"pods_delete",
+		"pods_diagnose",
 		"pods_top",
 		"pods_log",
 		"pods_run",
 		"pods_exec",
+		"pods_debug",
+		"pods_copy_from",
+		"pods_copy_to",
+		"port_forward_start",
+		"port_forward_list",
+		"port_forward_stop",
+		"api_resources",
 		"resources_list",
+		"resources_list_multi",
 		"resources_get",
+		"resources_describe",
+		"resources_tree",
+		"resources_explain",
 		"resources_create_or_update",
+		"resources_patch",
+		"resources_label",
+		"resources_annotate",
 		"resources_delete",
+		"resources_wait",
+		"workload_logs",
+		"workload_scale",
+		"rollout_restart",
+		"rollout_status",
+		"rollout_history",
+		"rollout_undo",
 	}
 	mcpCtx := &mcpContext{profile: &FullProfile{}}
 	testCaseWithContext(t, mcpCtx, func(c *mcpContext) {
@@ -1,18 +1,31 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
 )
 
+// resourcesWaitMaxTimeout is the maximum number of seconds resources_wait can wait for a condition
+const resourcesWaitMaxTimeout = 300
+
 func (s *Server) initResources() []server.ServerTool {
 	commonApiVersion := "v1 Pod, v1 Service, v1 Node, apps/v1 Deployment, networking.k8s.io/v1 Ingress"
 	if s.k.IsOpenShift(context.Background()) {
@@ -20,6 +33,18 @@ func (s *Server) initResources() []server.ServerTool {
 	}
 	commonApiVersion = fmt.Sprintf("(common apiVersion and kind include: %s)", commonApiVersion)
 	return []server.ServerTool{
+		{Tool: mcp.NewTool("api_resources",
+			mcp.WithDescription("List the API resources available in the current cluster (same as kubectl api-resources) with their name, short names, apiVersion, "+
+				"whether they are namespaced, kind and verbs. "+
+				"Use this tool to find the right apiVersion and kind of a resource (including CRDs) before using the rest of the resources tools"),
+			mcp.WithString("apiGroup",
+				mcp.Description("Optional API group to list the resources from (e.g. apps, networking.k8s.io, or core for the legacy core group). If not provided, will list resources from all groups")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("API Resources: List"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.apiResources},
 		{Tool: mcp.NewTool("resources_list",
 			mcp.WithDescription("List Kubernetes resources and objects in the current cluster by providing their apiVersion and kind and optionally the namespace and label selector\n"+
 				commonApiVersion),
@@ -35,12 +60,45 @@ func (s *Server) initResources() []server.ServerTool {
 				mcp.Description("Optional Namespace to retrieve the namespaced resources from (ignored in case of cluster scoped resources). If not provided, will list resources from all namespaces")),
 			mcp.WithString("labelSelector",
 				mcp.Description("Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("fieldSelector",
+				mcp.Description("Optional Kubernetes field selector (e.g. 'metadata.name=my-name' or 'status.phase=Running,spec.nodeName=node-1'), use this option when you want to filter the resources by field (supported fields depend on the kind)")),
+			mcp.WithNumber("limit",
+				mcp.Description("Optional maximum number of resources to return, if more resources are available the result includes a continue token to retrieve the next page")),
+			mcp.WithString("continue",
+				mcp.Description("Optional continue token returned by a previous call with limit, use this option to retrieve the next page of resources")),
+			mcp.WithString("jsonpath",
+				mcp.Description("Optional kubectl-compatible JSONPath template to print only the requested fields of the list instead of the full resources "+
+					"(e.g. '{.items[*].metadata.name}' or '{range .items[*]}{.metadata.name}{\"\\t\"}{.spec.replicas}{\"\\n\"}{end}'), can't be combined with customColumns")),
+			mcp.WithString("customColumns",
+				mcp.Description("Optional comma-separated list of HEADER:path columns to print the resources as a table with only the requested fields "+
+					"(e.g. 'NAME:.metadata.name,IMAGES:.spec.template.spec.containers[*].image'), can't be combined with jsonpath")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Resources: List"),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.resourcesList},
+		{Tool: mcp.NewTool("resources_list_multi",
+			mcp.WithDescription("List Kubernetes resources of several kinds in the current cluster at once (equivalent to kubectl get all) by providing their apiVersion and kind, "+
+				"or a category such as all, and optionally the namespace and label selector. Returns one table per kind, kinds without resources are omitted. "+
+				"Use this tool to find out what is running in a namespace with a single call"),
+			mcp.WithArray("kinds",
+				mcp.Description("Optional apiVersion/kind of the resources to list, required if category is not provided (e.g. [\"v1/Pod\", \"v1/Service\", \"apps/v1/Deployment\"])"),
+				stringItems,
+			),
+			mcp.WithString("category",
+				mcp.Description("Optional category of the resources to list as declared by the API discovery, required if kinds is not provided (e.g. all)"),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace to retrieve the namespaced resources from (ignored in case of cluster scoped resources). If not provided, will list resources from all namespaces")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the resources by label"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: List Multiple Kinds"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesListMulti},
 		{Tool: mcp.NewTool("resources_get",
 			mcp.WithDescription("Get a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name\n"+
 				commonApiVersion),
@@ -56,12 +114,84 @@ func (s *Server) initResources() []server.ServerTool {
 				mcp.Description("Optional Namespace to retrieve the namespaced resource from (ignored in case of cluster scoped resources). If not provided, will get resource from configured namespace"),
 			),
 			mcp.WithString("name", mcp.Description("Name of the resource"), mcp.Required()),
+			mcp.WithString("jsonpath",
+				mcp.Description("Optional kubectl-compatible JSONPath template or field path to print only the requested fields instead of the full resource "+
+					"(e.g. '.spec.template.spec.containers[*].image' or '{.metadata.name}{\" \"}{.status.readyReplicas}')")),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Resources: Get"),
 			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithDestructiveHintAnnotation(false),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.resourcesGet},
+		{Tool: mcp.NewTool("resources_describe",
+			mcp.WithDescription("Describe a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name. "+
+				"Returns a human-readable description (same as kubectl describe) including the related events, conditions, "+
+				"and related data such as the Pod template of workloads or the endpoints of services\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace to describe the namespaced resource from (ignored in case of cluster scoped resources). If not provided, will describe resource from configured namespace"),
+			),
+			mcp.WithString("name", mcp.Description("Name of the resource"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Describe"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesDescribe},
+		{Tool: mcp.NewTool("resources_tree",
+			mcp.WithDescription("Get the tree of resources owned, directly or transitively through ownerReferences, by a Kubernetes resource in the current cluster (same as kubectl tree), "+
+				"including the readiness status of each of them. "+
+				"Use this tool to find the resources managed by a workload or an operator custom resource (e.g. Deployment -> ReplicaSet -> Pod)\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will get resource from configured namespace"),
+			),
+			mcp.WithString("name", mcp.Description("Name of the resource"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Tree"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesTree},
+		{Tool: mcp.NewTool("resources_explain",
+			mcp.WithDescription("Explain the fields of a Kubernetes resource kind, or of one of its nested fields, by providing its apiVersion, kind, and optionally the field path. "+
+				"Returns the documentation (same as kubectl explain) rendered from the OpenAPI v3 schema published by the current cluster, including CRDs. "+
+				"Use this tool to check the available fields and their types before creating or updating a resource\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("field",
+				mcp.Description("Optional dot-separated path of the field to explain (e.g. spec.template.spec.containers). If not provided, will explain the top-level fields of the kind")),
+			mcp.WithBoolean("recursive",
+				mcp.Description("Optional, print the nested fields recursively (default false)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Explain"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesExplain},
 		{Tool: mcp.NewTool("resources_create_or_update",
 			mcp.WithDescription("Create or update a Kubernetes resource in the current cluster by providing a YAML or JSON representation of the resource\n"+
 				commonApiVersion),
@@ -69,6 +199,13 @@ func (s *Server) initResources() []server.ServerTool {
 				mcp.Description("A JSON or YAML containing a representation of the Kubernetes resource. Should include top-level fields such as apiVersion,kind,metadata, and spec"),
 				mcp.Required(),
 			),
+			mcp.WithBoolean("dryRun",
+				mcp.Description("If true, submit the resources with server-side dry-run and return the result without persisting any change (Optional, defaults to false)"),
+			),
+			mcp.WithBoolean("diff",
+				mcp.Description("If true, submit the resources with server-side dry-run and return a unified diff between the live resources and the dry-run result "+
+					"without persisting any change, use this option to preview the changes before applying them (Optional, defaults to false)"),
+			),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Resources: Create or Update"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -76,8 +213,112 @@ func (s *Server) initResources() []server.ServerTool {
 			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.resourcesCreateOrUpdate},
+		{Tool: mcp.NewTool("resources_patch",
+			mcp.WithDescription("Patch a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, its name, and a JSON, merge or strategic merge patch. "+
+				"Only the fields included in the patch are changed, use this tool instead of resources_create_or_update to update specific fields of an existing resource\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace to patch the namespaced resource in (ignored in case of cluster scoped resources). If not provided, will patch resource in configured namespace"),
+			),
+			mcp.WithString("name", mcp.Description("Name of the resource"), mcp.Required()),
+			mcp.WithString("patchType",
+				mcp.Description("Type of the patch: json (RFC 6902 JSON patch), merge (RFC 7386 JSON merge patch), or strategic (Kubernetes strategic merge patch, not supported by custom resources) "+
+					"(Optional, defaults to strategic)"),
+				mcp.Enum("json", "merge", "strategic"),
+			),
+			mcp.WithString("patch",
+				mcp.Description("A JSON or YAML containing the patch. "+
+					`Example for merge and strategic: {"spec":{"replicas":3}}. Example for json: [{"op":"replace","path":"/spec/replicas","value":3}]`),
+				mcp.Required(),
+			),
+			mcp.WithString("subresource",
+				mcp.Description("Optional subresource to patch instead of the resource itself"),
+				mcp.Enum("status", "scale"),
+			),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Patch"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesPatch},
+		{Tool: mcp.NewTool("resources_label",
+			mcp.WithDescription("Add, update or remove the labels of a Kubernetes resource, or of every resource matching a label selector, in the current cluster (same as kubectl label). "+
+				"Only the provided labels are changed (merge patch), the rest of the resource is left untouched\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resources (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resources (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace of the namespaced resources (ignored in case of cluster scoped resources). If not provided, will use the configured namespace"),
+			),
+			mcp.WithString("name", mcp.Description("Name of the resource to label (Optional, either name or labelSelector is required)")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Kubernetes label selector to label every matching resource (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)') (Optional, either name or labelSelector is required)"),
+				mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]"),
+			),
+			mcp.WithObject("labels", mcp.Description("Labels to add or update as key-value pairs (Optional). "+
+				`Example: {"team": "payments", "cost-center": "1234"}`)),
+			mcp.WithArray("remove", mcp.Description("Keys of the labels to remove (Optional). "+
+				`Example: ["team"]`), stringItems),
+			mcp.WithBoolean("overwrite", mcp.Description("If true, update the labels that already have a different value, "+
+				"otherwise the operation fails without changing any resource (Optional, defaults to false, same as kubectl label --overwrite)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Label"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesLabel},
+		{Tool: mcp.NewTool("resources_annotate",
+			mcp.WithDescription("Add, update or remove the annotations of a Kubernetes resource, or of every resource matching a label selector, in the current cluster (same as kubectl annotate). "+
+				"Only the provided annotations are changed (merge patch), the rest of the resource is left untouched\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resources (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resources (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace of the namespaced resources (ignored in case of cluster scoped resources). If not provided, will use the configured namespace"),
+			),
+			mcp.WithString("name", mcp.Description("Name of the resource to annotate (Optional, either name or labelSelector is required)")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Kubernetes label selector to annotate every matching resource (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)') (Optional, either name or labelSelector is required)"),
+				mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]"),
+			),
+			mcp.WithObject("annotations", mcp.Description("Annotations to add or update as key-value pairs (Optional). "+
+				`Example: {"description": "Payments API", "example.com/owner": "payments-team"}`)),
+			mcp.WithArray("remove", mcp.Description("Keys of the annotations to remove (Optional). "+
+				`Example: ["example.com/owner"]`), stringItems),
+			mcp.WithBoolean("overwrite", mcp.Description("If true, update the annotations that already have a different value, "+
+				"otherwise the operation fails without changing any resource (Optional, defaults to false, same as kubectl annotate --overwrite)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Annotate"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesAnnotate},
 		{Tool: mcp.NewTool("resources_delete",
-			mcp.WithDescription("Delete a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name\n"+
+			mcp.WithDescription("Delete a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name, "+
+				"or every resource matching a label selector. Optionally wait until the resources are removed, reporting the finalizers that block resources stuck terminating\n"+
 				commonApiVersion),
 			mcp.WithString("apiVersion",
 				mcp.Description("apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
@@ -90,7 +331,24 @@ func (s *Server) initResources() []server.ServerTool {
 			mcp.WithString("namespace",
 				mcp.Description("Optional Namespace to delete the namespaced resource from (ignored in case of cluster scoped resources). If not provided, will delete resource from configured namespace"),
 			),
-			mcp.WithString("name", mcp.Description("Name of the resource"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the resource (Optional, required if labelSelector is not provided)")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Kubernetes label selector (e.g. 'app=myapp,env=prod') to delete every matching resource (Optional, can't be combined with name)"),
+				mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]"),
+			),
+			mcp.WithString("propagationPolicy",
+				mcp.Description("Whether and how the dependents of the resource (e.g. the Pods of a ReplicaSet) are deleted: "+
+					"Foreground (the dependents are deleted before the resource), Background (the dependents are deleted after the resource), "+
+					"or Orphan (the dependents are kept) (Optional, defaults to the server default for the kind)"),
+				mcp.Enum("Foreground", "Background", "Orphan"),
+			),
+			mcp.WithNumber("gracePeriodSeconds", mcp.Description("Duration in seconds the resources have to terminate gracefully, 0 deletes them immediately (Optional, defaults to the resource default)"), mcp.Min(0)),
+			mcp.WithBoolean("wait", mcp.Description("If true, wait until the resources are removed from the cluster, i.e. until their finalizers complete, "+
+				"and report the finalizers blocking the resources that are still terminating when the timeout expires (Optional, defaults to false)")),
+			mcp.WithNumber("timeout",
+				mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the resources to be removed if wait is true (Optional, defaults to %d, max %d)", int(kubernetes.DefaultResourcesWaitTimeout.Seconds()), resourcesWaitMaxTimeout)),
+				mcp.Min(1), mcp.Max(resourcesWaitMaxTimeout),
+			),
 			// Tool annotations
 			mcp.WithTitleAnnotation("Resources: Delete"),
 			mcp.WithReadOnlyHintAnnotation(false),
@@ -98,25 +356,95 @@ func (s *Server) initResources() []server.ServerTool {
 			mcp.WithIdempotentHintAnnotation(true),
 			mcp.WithOpenWorldHintAnnotation(true),
 		), Handler: s.resourcesDelete},
+		{Tool: mcp.NewTool("resources_wait",
+			mcp.WithDescription("Wait for a Kubernetes resource, or for every resource matching a label selector, to meet a condition in the current cluster (same as kubectl wait). "+
+				"Blocks until the condition is met or the timeout expires and returns the final state of the resources. "+
+				"Use this tool to verify that a change was applied (e.g. a Deployment is available, a Job is complete, or a resource is deleted) instead of repeatedly getting the resource\n"+
+				commonApiVersion),
+			mcp.WithString("apiVersion",
+				mcp.Description("apiVersion of the resources (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)"),
+				mcp.Required(),
+			),
+			mcp.WithString("kind",
+				mcp.Description("kind of the resources (examples of valid kind are: Pod, Service, Deployment, Ingress)"),
+				mcp.Required(),
+			),
+			mcp.WithString("namespace",
+				mcp.Description("Optional Namespace of the namespaced resources (ignored in case of cluster scoped resources). If not provided, will wait for resources in the configured namespace")),
+			mcp.WithString("name", mcp.Description("Name of the resource to wait for. Required if labelSelector is not provided")),
+			mcp.WithString("labelSelector",
+				mcp.Description("Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)') to match the resources to wait for. Required if name is not provided"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("for",
+				mcp.Description("Condition to wait for, one of: 'delete', 'condition=<type>[=<status>]' (e.g. 'condition=Available', 'condition=Ready=False'), "+
+					"or 'jsonpath={<path>}=<value>' (e.g. 'jsonpath={.status.phase}=Running')"),
+				mcp.Required(),
+			),
+			mcp.WithNumber("timeout",
+				mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the condition (Optional, defaults to %d, max %d)", int(kubernetes.DefaultResourcesWaitTimeout.Seconds()), resourcesWaitMaxTimeout)),
+				mcp.Min(1), mcp.Max(resourcesWaitMaxTimeout)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Resources: Wait"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.resourcesWait},
 	}
 }
 
+func (s *Server) apiResources(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	apiGroup := ctr.GetArguments()["apiGroup"]
+	if apiGroup == nil {
+		apiGroup = ""
+	}
+	g, ok := apiGroup.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("apiGroup is not a string")), nil
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apiResourceLists, err := derived.APIResources(g)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list API resources: %v", err)), nil
+	}
+	if len(apiResourceLists) == 0 {
+		return NewTextResult("No API resources found", nil), nil
+	}
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 10, 4, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSHORTNAMES\tAPIVERSION\tNAMESPACED\tKIND\tVERBS")
+	for _, apiResourceList := range apiResourceLists {
+		for _, apiResource := range apiResourceList.APIResources {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n", apiResource.Name, strings.Join(apiResource.ShortNames, ","),
+				apiResourceList.GroupVersion, apiResource.Namespaced, apiResource.Kind, strings.Join(apiResource.Verbs, ","))
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return NewTextResult("", fmt.Errorf("failed to list API resources: %v", err)), nil
+	}
+	return NewTextResult(buf.String(), nil), nil
+}
+
 func (s *Server) resourcesList(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	namespace := ctr.GetArguments()["namespace"]
 	if namespace == nil {
 		namespace = ""
 	}
-	labelSelector := ctr.GetArguments()["labelSelector"]
-	resourceListOptions := kubernetes.ResourceListOptions{
-		AsTable: s.configuration.ListOutput.AsTable(),
+	listOptions, err := parseListOptions(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", err), nil
 	}
-
-	if labelSelector != nil {
-		l, ok := labelSelector.(string)
-		if !ok {
-			return NewTextResult("", fmt.Errorf("labelSelector is not a string")), nil
-		}
-		resourceListOptions.LabelSelector = l
+	jsonPath, _ := ctr.GetArguments()["jsonpath"].(string)
+	customColumns, _ := ctr.GetArguments()["customColumns"].(string)
+	if jsonPath != "" && customColumns != "" {
+		return NewTextResult("", errors.New("failed to list resources, jsonpath can't be combined with customColumns")), nil
+	}
+	resourceListOptions := kubernetes.ResourceListOptions{
+		ListOptions: listOptions,
+		// Projections are evaluated against the complete resources
+		AsTable: s.configuration.ListOutput.AsTable() && jsonPath == "" && customColumns == "",
 	}
 	gvk, err := parseGroupVersionKind(ctr.GetArguments())
 	if err != nil {
@@ -136,7 +464,75 @@ func (s *Server) resourcesList(ctx context.Context, ctr mcp.CallToolRequest) (*m
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to list resources: %v", err)), nil
 	}
-	return NewTextResult(s.configuration.ListOutput.PrintObj(ret)), nil
+	switch {
+	case jsonPath != "":
+		return NewTextResult(printList(ret, func(obj runtime.Unstructured) (string, error) { return output.PrintJsonPath(obj, jsonPath) })), nil
+	case customColumns != "":
+		return NewTextResult(printList(ret, func(obj runtime.Unstructured) (string, error) { return output.PrintCustomColumns(obj, customColumns) })), nil
+	}
+	return NewTextResult(printList(ret, s.configuration.ListOutput.PrintObj)), nil
+}
+
+func (s *Server) resourcesListMulti(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, _ := ctr.GetArguments()["namespace"].(string)
+	category, _ := ctr.GetArguments()["category"].(string)
+	kinds := stringSlice(ctr.GetArguments()["kinds"])
+	if len(kinds) == 0 && category == "" {
+		return NewTextResult("", errors.New("failed to list resources, missing argument kinds or category")), nil
+	}
+	if len(kinds) > 0 && category != "" {
+		return NewTextResult("", errors.New("failed to list resources, kinds can't be combined with category")), nil
+	}
+	var gvks []schema.GroupVersionKind
+	for _, kind := range kinds {
+		separator := strings.LastIndex(kind, "/")
+		if separator <= 0 || separator == len(kind)-1 {
+			return NewTextResult("", fmt.Errorf("failed to list resources, invalid kind %s, expected apiVersion/kind (e.g. apps/v1/Deployment)", kind)), nil
+		}
+		gv, err := schema.ParseGroupVersion(kind[:separator])
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to list resources, invalid apiVersion in kind %s", kind)), nil
+		}
+		gvks = append(gvks, gv.WithKind(kind[separator+1:]))
+	}
+	listOptions, err := parseListOptions(map[string]interface{}{"labelSelector": ctr.GetArguments()["labelSelector"]})
+	if err != nil {
+		return NewTextResult("", err), nil
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if category != "" {
+		if gvks, err = derived.ResourcesForCategory(category); err != nil {
+			return NewTextResult("", fmt.Errorf("failed to list resources: %v", err)), nil
+		}
+	}
+	results := derived.ResourcesListMulti(ctx, gvks, namespace, kubernetes.ResourceListOptions{ListOptions: listOptions, AsTable: true})
+	ret := strings.Builder{}
+	var empty []string
+	for _, result := range results {
+		id := result.GroupVersionKind.GroupVersion().String() + "/" + result.GroupVersionKind.Kind
+		if result.Err != nil {
+			ret.WriteString(fmt.Sprintf("# Failed to list %s: %v\n", id, result.Err))
+			continue
+		}
+		if rows, _, _ := unstructured.NestedSlice(result.List.UnstructuredContent(), "rows"); len(rows) == 0 {
+			empty = append(empty, id)
+			continue
+		}
+		table, printErr := output.Table.PrintObj(result.List)
+		if printErr != nil {
+			ret.WriteString(fmt.Sprintf("# Failed to print %s: %v\n", id, printErr))
+			continue
+		}
+		ret.WriteString(fmt.Sprintf("# %s\n%s\n", id, table))
+	}
+	if len(empty) > 0 {
+		ret.WriteString(fmt.Sprintf("# No resources found for %s\n", strings.Join(empty, ", ")))
+	}
+	return NewTextResult(ret.String(), nil), nil
 }
 
 func (s *Server) resourcesGet(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -171,9 +567,102 @@ func (s *Server) resourcesGet(ctx context.Context, ctr mcp.CallToolRequest) (*mc
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to get resource: %v", err)), nil
 	}
+	if jsonPath, _ := ctr.GetArguments()["jsonpath"].(string); jsonPath != "" {
+		return NewTextResult(output.PrintJsonPath(ret, jsonPath)), nil
+	}
 	return NewTextResult(output.MarshalYaml(ret)), nil
 }
 
+func (s *Server) resourcesDescribe(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ctr.GetArguments()["namespace"]
+	if namespace == nil {
+		namespace = ""
+	}
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to describe resource, %s", err)), nil
+	}
+	name := ctr.GetArguments()["name"]
+	if name == nil {
+		return NewTextResult("", errors.New("failed to describe resource, missing argument name")), nil
+	}
+
+	ns, ok := namespace.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("namespace is not a string")), nil
+	}
+
+	n, ok := name.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("name is not a string")), nil
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := derived.ResourcesDescribe(ctx, gvk, ns, n)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to describe resource: %v", err)), nil
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+func (s *Server) resourcesExplain(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to explain resource, %s", err)), nil
+	}
+	field := ctr.GetArguments()["field"]
+	if field == nil {
+		field = ""
+	}
+	f, ok := field.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("field is not a string")), nil
+	}
+	recursive := ctr.GetArguments()["recursive"]
+	if recursive == nil {
+		recursive = false
+	}
+	r, ok := recursive.(bool)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("recursive is not a boolean")), nil
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := derived.ResourcesExplain(gvk, f, r)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to explain resource: %v", err)), nil
+	}
+	return NewTextResult(ret, nil), nil
+}
+
+func (s *Server) resourcesTree(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get resource tree, %s", err)), nil
+	}
+	namespace, _ := ctr.GetArguments()["namespace"].(string)
+	name, _ := ctr.GetArguments()["name"].(string)
+	if name == "" {
+		return NewTextResult("", errors.New("failed to get resource tree, missing argument name")), nil
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := derived.ResourcesTree(ctx, gvk, namespace, name)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get resource tree: %v", err)), nil
+	}
+	return NewTextResult(printResourcesTree(tree)), nil
+}
+
 func (s *Server) resourcesCreateOrUpdate(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	resource := ctr.GetArguments()["resource"]
 	if resource == nil || resource == "" {
@@ -185,11 +674,24 @@ func (s *Server) resourcesCreateOrUpdate(ctx context.Context, ctr mcp.CallToolRe
 		return NewTextResult("", fmt.Errorf("resource is not a string")), nil
 	}
 
+	dryRun, _ := ctr.GetArguments()["dryRun"].(bool)
+	diff, _ := ctr.GetArguments()["diff"].(bool)
+
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
 		return nil, err
 	}
-	resources, err := derived.ResourcesCreateOrUpdate(ctx, r)
+	if diff {
+		ret, err := derived.ResourcesDiff(ctx, r)
+		if err != nil {
+			return NewTextResult("", fmt.Errorf("failed to diff resources: %v", err)), nil
+		}
+		if ret == "" {
+			return NewTextResult("# No changes would be applied to the resources (dry run)\n", nil), nil
+		}
+		return NewTextResult("# The following changes (unified diff) would be applied to the resources (dry run)\n"+ret, nil), nil
+	}
+	resources, err := derived.ResourcesCreateOrUpdate(ctx, r, kubernetes.ResourcesCreateOrUpdateOptions{DryRun: dryRun})
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to create or update resources: %v", err)), nil
 	}
@@ -197,21 +699,45 @@ func (s *Server) resourcesCreateOrUpdate(ctx context.Context, ctr mcp.CallToolRe
 	if err != nil {
 		err = fmt.Errorf("failed to create or update resources:: %v", err)
 	}
+	if dryRun {
+		return NewTextResult("# The following resources (YAML) would be created or updated (dry run)\n"+marshalledYaml, err), nil
+	}
 	return NewTextResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
 }
 
-func (s *Server) resourcesDelete(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (s *Server) resourcesPatch(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	namespace := ctr.GetArguments()["namespace"]
 	if namespace == nil {
 		namespace = ""
 	}
 	gvk, err := parseGroupVersionKind(ctr.GetArguments())
 	if err != nil {
-		return NewTextResult("", fmt.Errorf("failed to delete resource, %s", err)), nil
+		return NewTextResult("", fmt.Errorf("failed to patch resource, %s", err)), nil
 	}
 	name := ctr.GetArguments()["name"]
 	if name == nil {
-		return NewTextResult("", errors.New("failed to delete resource, missing argument name")), nil
+		return NewTextResult("", errors.New("failed to patch resource, missing argument name")), nil
+	}
+	patch := ctr.GetArguments()["patch"]
+	if patch == nil || patch == "" {
+		return NewTextResult("", errors.New("failed to patch resource, missing argument patch")), nil
+	}
+	patchType := types.StrategicMergePatchType
+	if v, ok := ctr.GetArguments()["patchType"].(string); ok && v != "" {
+		switch v {
+		case "json":
+			patchType = types.JSONPatchType
+		case "merge":
+			patchType = types.MergePatchType
+		case "strategic":
+			patchType = types.StrategicMergePatchType
+		default:
+			return NewTextResult("", fmt.Errorf("failed to patch resource, invalid patchType %s", v)), nil
+		}
+	}
+	subresource, _ := ctr.GetArguments()["subresource"].(string)
+	if subresource != "" && subresource != "status" && subresource != "scale" {
+		return NewTextResult("", fmt.Errorf("failed to patch resource, invalid subresource %s", subresource)), nil
 	}
 
 	ns, ok := namespace.(string)
@@ -224,15 +750,335 @@ func (s *Server) resourcesDelete(ctx context.Context, ctr mcp.CallToolRequest) (
 		return NewTextResult("", fmt.Errorf("name is not a string")), nil
 	}
 
+	p, ok := patch.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("patch is not a string")), nil
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := derived.ResourcesPatch(ctx, gvk, ns, n, patchType, p, subresource)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to patch resource: %v", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to patch resource: %v", err)
+	}
+	return NewTextResult("# The following resource (YAML) has been patched successfully\n"+marshalledYaml, err), nil
+}
+
+func (s *Server) resourcesLabel(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.resourcesUpdateMetadata(ctx, ctr, "label", "labels")
+}
+
+func (s *Server) resourcesAnnotate(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.resourcesUpdateMetadata(ctx, ctr, "annotate", "annotations")
+}
+
+// resourcesUpdateMetadata handles resources_label and resources_annotate, field is the metadata field to update (labels or annotations)
+func (s *Server) resourcesUpdateMetadata(ctx context.Context, ctr mcp.CallToolRequest, verb, field string) (*mcp.CallToolResult, error) {
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to %s resources, %s", verb, err)), nil
+	}
+	namespace, _ := ctr.GetArguments()["namespace"].(string)
+	options := kubernetes.ResourcesMetadataOptions{
+		Set:    stringMap(ctr.GetArguments()[field]),
+		Remove: stringSlice(ctr.GetArguments()["remove"]),
+	}
+	options.Name, _ = ctr.GetArguments()["name"].(string)
+	options.LabelSelector, _ = ctr.GetArguments()["labelSelector"].(string)
+	options.Overwrite, _ = ctr.GetArguments()["overwrite"].(bool)
+	if options.Name == "" && options.LabelSelector == "" {
+		return NewTextResult("", fmt.Errorf("failed to %s resources, missing argument name or labelSelector", verb)), nil
+	}
+	if len(options.Set) == 0 && len(options.Remove) == 0 {
+		return NewTextResult("", fmt.Errorf("failed to %s resources, missing argument %s or remove", verb, field)), nil
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var updated []*unstructured.Unstructured
+	if field == "labels" {
+		updated, err = derived.ResourcesLabel(ctx, gvk, namespace, options)
+	} else {
+		updated, err = derived.ResourcesAnnotate(ctx, gvk, namespace, options)
+	}
+	// Only the resulting metadata is returned, the full resources may be too verbose when using a label selector
+	summary := make([]map[string]interface{}, 0, len(updated))
+	for _, obj := range updated {
+		metadata := map[string]interface{}{"name": obj.GetName()}
+		if obj.GetNamespace() != "" {
+			metadata["namespace"] = obj.GetNamespace()
+		}
+		metadata[field], _, _ = unstructured.NestedStringMap(obj.Object, "metadata", field)
+		summary = append(summary, map[string]interface{}{"apiVersion": obj.GetAPIVersion(), "kind": obj.GetKind(), "metadata": metadata})
+	}
+	marshalledYaml, yamlErr := output.MarshalYaml(summary)
+	if err != nil && len(updated) > 0 {
+		return NewTextResult("", fmt.Errorf("failed to %s resources: %v, the following resources were already updated:\n%s", verb, err, marshalledYaml)), nil
+	} else if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to %s resources: %v", verb, err)), nil
+	}
+	if yamlErr != nil {
+		yamlErr = fmt.Errorf("failed to %s resources: %v", verb, yamlErr)
+	}
+	return NewTextResult(fmt.Sprintf("# The %s of the following resources (YAML) have been updated successfully\n", field)+marshalledYaml, yamlErr), nil
+}
+
+func (s *Server) resourcesDelete(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ctr.GetArguments()["namespace"]
+	if namespace == nil {
+		namespace = ""
+	}
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to delete resource, %s", err)), nil
+	}
+	resourcesDeleteOptions := kubernetes.ResourcesDeleteOptions{}
+	resourcesDeleteOptions.Name, _ = ctr.GetArguments()["name"].(string)
+	resourcesDeleteOptions.LabelSelector, _ = ctr.GetArguments()["labelSelector"].(string)
+	if resourcesDeleteOptions.Name == "" && resourcesDeleteOptions.LabelSelector == "" {
+		return NewTextResult("", errors.New("failed to delete resource, missing argument name or labelSelector")), nil
+	}
+	if resourcesDeleteOptions.Name != "" && resourcesDeleteOptions.LabelSelector != "" {
+		return NewTextResult("", errors.New("failed to delete resource, name can't be combined with labelSelector")), nil
+	}
+	if v, ok := ctr.GetArguments()["propagationPolicy"].(string); ok && v != "" {
+		propagationPolicy := metav1.DeletionPropagation(v)
+		if propagationPolicy != metav1.DeletePropagationForeground && propagationPolicy != metav1.DeletePropagationBackground && propagationPolicy != metav1.DeletePropagationOrphan {
+			return NewTextResult("", fmt.Errorf("failed to delete resource, invalid propagationPolicy %s", v)), nil
+		}
+		resourcesDeleteOptions.PropagationPolicy = &propagationPolicy
+	}
+	if v, ok := ctr.GetArguments()["gracePeriodSeconds"].(float64); ok {
+		if v < 0 {
+			return NewTextResult("", errors.New("failed to delete resource, gracePeriodSeconds must be greater than or equal to 0")), nil
+		}
+		resourcesDeleteOptions.GracePeriodSeconds = ptr.To(int64(v))
+	}
+	resourcesDeleteOptions.Wait, _ = ctr.GetArguments()["wait"].(bool)
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v < 1 || v > resourcesWaitMaxTimeout {
+			return NewTextResult("", fmt.Errorf("failed to delete resource, timeout must be between 1 and %d seconds", resourcesWaitMaxTimeout)), nil
+		}
+		resourcesDeleteOptions.WaitTimeout = time.Duration(v) * time.Second
+	}
+
+	ns, ok := namespace.(string)
+	if !ok {
+		return NewTextResult("", fmt.Errorf("namespace is not a string")), nil
+	}
+
 	derived, err := s.k.Derived(ctx)
 	if err != nil {
 		return nil, err
 	}
-	err = derived.ResourcesDelete(ctx, gvk, ns, n)
+	ret, err := derived.ResourcesDelete(ctx, gvk, ns, resourcesDeleteOptions)
 	if err != nil {
 		return NewTextResult("", fmt.Errorf("failed to delete resource: %v", err)), nil
 	}
-	return NewTextResult("Resource deleted successfully", err), nil
+	if len(ret.Deleted) == 0 {
+		return NewTextResult(fmt.Sprintf("No %s resources found matching label selector %s", gvk.Kind, resourcesDeleteOptions.LabelSelector), nil), nil
+	}
+	removed := ""
+	if resourcesDeleteOptions.Wait && len(ret.Pending) == 0 {
+		removed = " and removed from the cluster"
+	}
+	var result string
+	if resourcesDeleteOptions.Name != "" {
+		result = "Resource deleted successfully" + removed + "\n"
+	} else {
+		result = fmt.Sprintf("# The following %d %s resources have been deleted%s\n- %s\n", len(ret.Deleted), gvk.Kind, removed, strings.Join(ret.Deleted, "\n- "))
+	}
+	if len(ret.Pending) > 0 {
+		timeout := resourcesDeleteOptions.WaitTimeout
+		if timeout <= 0 {
+			timeout = kubernetes.DefaultResourcesWaitTimeout
+		}
+		result += fmt.Sprintf("# Timed out after %s waiting for the following %d resources to be removed, they are still terminating\n", timeout, len(ret.Pending)) +
+			printResourcesDeletePending(ret.Pending)
+	}
+	return NewTextResult(strings.TrimSuffix(result, "\n"), nil), nil
+}
+
+// printResourcesDeletePending prints the resources that are still terminating along with the finalizers blocking them
+// and their true conditions (e.g. NamespaceContentRemaining or NamespaceFinalizersRemaining for Namespaces)
+func printResourcesDeletePending(pending []*unstructured.Unstructured) string {
+	ret := ""
+	for _, obj := range pending {
+		ret += "- " + obj.GetName()
+		if deletionTimestamp := obj.GetDeletionTimestamp(); deletionTimestamp != nil {
+			ret += " (terminating for " + duration.HumanDuration(time.Since(deletionTimestamp.Time)) + ")"
+		}
+		ret += "\n"
+		// Namespaces are also blocked by the finalizers in their spec
+		specFinalizers, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "finalizers")
+		if len(obj.GetFinalizers()) > 0 {
+			ret += "  - finalizers: " + strings.Join(obj.GetFinalizers(), ", ") + "\n"
+		}
+		if len(specFinalizers) > 0 {
+			ret += "  - spec finalizers: " + strings.Join(specFinalizers, ", ") + "\n"
+		}
+		if len(obj.GetFinalizers()) == 0 && len(specFinalizers) == 0 {
+			ret += "  - no finalizers, the resource is still terminating (e.g. the termination grace period hasn't expired)\n"
+		}
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, rawCondition := range conditions {
+			condition, ok := rawCondition.(map[string]interface{})
+			if !ok || condition["status"] != "True" {
+				continue
+			}
+			ret += fmt.Sprintf("  - %v: %v\n", condition["type"], condition["message"])
+		}
+	}
+	return ret
+}
+
+func (s *Server) resourcesWait(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gvk, err := parseGroupVersionKind(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to wait for resources, %s", err)), nil
+	}
+	namespace, _ := ctr.GetArguments()["namespace"].(string)
+	options := kubernetes.ResourcesWaitOptions{Timeout: kubernetes.DefaultResourcesWaitTimeout}
+	options.Name, _ = ctr.GetArguments()["name"].(string)
+	options.LabelSelector, _ = ctr.GetArguments()["labelSelector"].(string)
+	if options.Name == "" && options.LabelSelector == "" {
+		return NewTextResult("", errors.New("failed to wait for resources, missing argument name or labelSelector")), nil
+	}
+	if options.Name != "" && options.LabelSelector != "" {
+		return NewTextResult("", errors.New("failed to wait for resources, name can't be combined with labelSelector")), nil
+	}
+	if options.For, _ = ctr.GetArguments()["for"].(string); options.For == "" {
+		return NewTextResult("", errors.New("failed to wait for resources, missing argument for")), nil
+	}
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		if v < 1 || v > resourcesWaitMaxTimeout {
+			return NewTextResult("", fmt.Errorf("failed to wait for resources, timeout must be between 1 and %d seconds", resourcesWaitMaxTimeout)), nil
+		}
+		options.Timeout = time.Duration(v) * time.Second
+	}
+
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := derived.ResourcesWait(ctx, gvk, namespace, options)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to wait for resources: %v", err)), nil
+	}
+	if len(results) == 0 {
+		return NewTextResult(fmt.Sprintf("No %s resources found, the condition %s is met", gvk.Kind, options.For), nil), nil
+	}
+	var pending []string
+	var objects []*unstructured.Unstructured
+	for _, result := range results {
+		if !result.Met {
+			pending = append(pending, result.Name)
+		}
+		if result.Object != nil {
+			objects = append(objects, result.Object)
+		}
+	}
+	ret := fmt.Sprintf("# The condition %s is met by all the resources\n", options.For)
+	if len(pending) > 0 {
+		ret = fmt.Sprintf("# Timed out after %s waiting for the condition %s, not met by: %s\n", options.Timeout, options.For, strings.Join(pending, ", "))
+	}
+	if len(objects) == 0 {
+		return NewTextResult(ret, nil), nil
+	}
+	yamlObjects, err := output.MarshalYaml(objects)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to wait for resources: %v", err)), nil
+	}
+	return NewTextResult(ret+"# Final state of the resources (YAML)\n"+yamlObjects, nil), nil
+}
+
+// printResourcesTree prints the tree as a table with a row per resource, the descendants are indented below their owners (same as kubectl tree)
+func printResourcesTree(tree *kubernetes.ResourcesTree) (string, error) {
+	buf := new(bytes.Buffer)
+	for _, failure := range tree.ListErrors {
+		_, _ = fmt.Fprintf(buf, "# Failed to list %s/%s, the tree may be incomplete: %v\n", failure.GroupVersionKind.GroupVersion().String(), failure.GroupVersionKind.Kind, failure.Err)
+	}
+	w := tabwriter.NewWriter(buf, 10, 4, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAMESPACE\tNAME\tREADY\tREASON\tAGE")
+	var printNode func(node *kubernetes.ResourcesTreeNode, prefix, childrenPrefix string)
+	printNode = func(node *kubernetes.ResourcesTreeNode, prefix, childrenPrefix string) {
+		age := "<unknown>"
+		if creationTimestamp := node.Object.GetCreationTimestamp(); !creationTimestamp.IsZero() {
+			age = duration.HumanDuration(time.Since(creationTimestamp.Time))
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s%s/%s\t%s\t%s\t%s\n", node.Object.GetNamespace(), prefix, node.Object.GetKind(), node.Object.GetName(), node.Ready, node.Reason, age)
+		for i, child := range node.Children {
+			if i == len(node.Children)-1 {
+				printNode(child, childrenPrefix+"└─", childrenPrefix+"  ")
+			} else {
+				printNode(child, childrenPrefix+"├─", childrenPrefix+"│ ")
+			}
+		}
+	}
+	printNode(tree.Root, "", "")
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// printList prints the list with the provided printer, preceded by the continue token if the list is truncated
+func printList(list runtime.Unstructured, printObj func(obj runtime.Unstructured) (string, error)) (string, error) {
+	ret, err := printObj(list)
+	if err != nil {
+		return ret, err
+	}
+	continueToken, _, _ := unstructured.NestedString(list.UnstructuredContent(), "metadata", "continue")
+	if continueToken == "" {
+		return ret, nil
+	}
+	remaining := ""
+	if remainingItemCount, found, _ := unstructured.NestedInt64(list.UnstructuredContent(), "metadata", "remainingItemCount"); found {
+		remaining = fmt.Sprintf(" (%d remaining)", remainingItemCount)
+	}
+	return fmt.Sprintf("# The list is truncated%s, use the continue token %s to retrieve the next page\n", remaining, continueToken) + ret, nil
+}
+
+// parseListOptions parses the arguments shared by the list tools to filter and paginate the results
+func parseListOptions(arguments map[string]interface{}) (metav1.ListOptions, error) {
+	listOptions := metav1.ListOptions{}
+	if labelSelector := arguments["labelSelector"]; labelSelector != nil {
+		l, ok := labelSelector.(string)
+		if !ok {
+			return listOptions, errors.New("labelSelector is not a string")
+		}
+		listOptions.LabelSelector = l
+	}
+	if fieldSelector := arguments["fieldSelector"]; fieldSelector != nil {
+		f, ok := fieldSelector.(string)
+		if !ok {
+			return listOptions, errors.New("fieldSelector is not a string")
+		}
+		listOptions.FieldSelector = f
+	}
+	if limit := arguments["limit"]; limit != nil {
+		l, ok := limit.(float64)
+		if !ok || l <= 0 {
+			return listOptions, errors.New("limit must be a number greater than 0")
+		}
+		listOptions.Limit = int64(l)
+	}
+	if continueToken := arguments["continue"]; continueToken != nil {
+		c, ok := continueToken.(string)
+		if !ok {
+			return listOptions, errors.New("continue is not a string")
+		}
+		listOptions.Continue = c
+	}
+	return listOptions, nil
 }
 
 func parseGroupVersionKind(arguments map[string]interface{}) (*schema.GroupVersionKind, error) {
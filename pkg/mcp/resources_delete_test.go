@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResourcesDeleteOptions(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var deleteRequests, getRequests []string
+		var deleteBody string
+		deletedConfigMaps := map[string]bool{}
+		configMap := func(name string) *v1.ConfigMap {
+			uid := "uid-" + name
+			if deletedConfigMaps[name] {
+				// Recreated by a controller after the deletion
+				uid += "-recreated"
+			}
+			return &v1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(uid), Labels: map[string]string{"app": "web"}}}
+		}
+		stuckNamespace := func() *v1.Namespace {
+			return &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "ns-stuck", Labels: map[string]string{"team": "old"},
+					DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}},
+				Spec: v1.NamespaceSpec{Finalizers: []v1.FinalizerName{v1.FinalizerKubernetes}},
+				Status: v1.NamespaceStatus{Phase: v1.NamespaceTerminating, Conditions: []v1.NamespaceCondition{
+					{Type: v1.NamespaceDeletionDiscoveryFailure, Status: v1.ConditionFalse, Message: "All resources successfully discovered"},
+					{Type: v1.NamespaceContentRemaining, Status: v1.ConditionTrue, Message: "Some resources are remaining: widgets.example.com has 1 resource instances"},
+					{Type: v1.NamespaceFinalizersRemaining, Status: v1.ConditionTrue, Message: "Some content in the namespace has finalizers remaining: example.com/cleanup in 1 resource instances"},
+				}},
+			}
+		}
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"delete", "deletecollection", "get", "list", "watch"}},
+				{Name: "namespaces", Kind: "Namespace", Verbs: []string{"delete", "get", "list", "watch"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if req.Method == http.MethodDelete {
+				body, _ := io.ReadAll(req.Body)
+				deleteBody = string(body)
+				deleteRequests = append(deleteRequests, req.URL.Path+"?"+req.URL.RawQuery)
+				switch req.URL.Path {
+				case "/api/v1/namespaces":
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"MethodNotAllowed","code":405}`))
+					return
+				case "/api/v1/namespaces/default/configmaps":
+					deletedConfigMaps["web-config"], deletedConfigMaps["web-env"] = true, true
+				default:
+					deletedConfigMaps[path.Base(req.URL.Path)] = true
+				}
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+				return
+			}
+			if req.URL.Query().Get("watch") == "true" {
+				w.WriteHeader(http.StatusOK)
+				w.(http.Flusher).Flush()
+				<-req.Context().Done()
+				return
+			}
+			switch req.URL.Path {
+			case "/api/v1/namespaces/default/configmaps":
+				list := &v1.ConfigMapList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMapList"}, ListMeta: metav1.ListMeta{ResourceVersion: "1"}}
+				if req.URL.Query().Get("labelSelector") == "app=web" {
+					// web-env is removed once deleted, web-config is recreated with a new UID
+					list.Items = append(list.Items, *configMap("web-config"))
+					if !deletedConfigMaps["web-env"] {
+						list.Items = append(list.Items, *configMap("web-env"))
+					}
+				}
+				test.WriteObject(w, list)
+			case "/api/v1/namespaces/default/configmaps/web-config":
+				getRequests = append(getRequests, req.URL.Path)
+				test.WriteObject(w, configMap("web-config"))
+			case "/api/v1/namespaces":
+				list := &v1.NamespaceList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NamespaceList"}, ListMeta: metav1.ListMeta{ResourceVersion: "1"}}
+				if req.URL.Query().Get("labelSelector") == "team=old" || req.URL.Query().Get("fieldSelector") == "metadata.name=ns-stuck" {
+					list.Items = append(list.Items, *stuckNamespace())
+				}
+				test.WriteObject(w, list)
+			}
+		}))
+		t.Run("resources_delete with name and labelSelector returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "web-config", "labelSelector": "app=web"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to delete resource, name can't be combined with labelSelector" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_delete with invalid propagationPolicy returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "web-config", "propagationPolicy": "Cascade"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to delete resource, invalid propagationPolicy Cascade" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_delete with propagationPolicy and gracePeriodSeconds sends delete options", func(t *testing.T) {
+			deleteRequests, getRequests = nil, nil
+			toolResult, err := c.callTool("resources_delete", map[string]interface{}{
+				"apiVersion": "v1", "kind": "ConfigMap", "name": "web-config", "propagationPolicy": "Foreground", "gracePeriodSeconds": 0,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Resource deleted successfully" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if len(deleteRequests) != 1 || !strings.HasPrefix(deleteRequests[0], "/api/v1/namespaces/default/configmaps/web-config?") {
+				t.Errorf("unexpected delete requests %v", deleteRequests)
+			}
+			if !strings.Contains(deleteBody, `"gracePeriodSeconds":0`) || !strings.Contains(deleteBody, `"propagationPolicy":"Foreground"`) ||
+				strings.Contains(deleteBody, `"preconditions"`) {
+				t.Errorf("unexpected delete options %s", deleteBody)
+			}
+			if len(getRequests) > 0 {
+				t.Errorf("no get request expected without wait, got %v", getRequests)
+			}
+		})
+		t.Run("resources_delete with name and wait ignores the resource recreated with the same name", func(t *testing.T) {
+			deleteRequests = nil
+			clear(deletedConfigMaps)
+			toolResult, err := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "web-config", "wait": true, "timeout": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Resource deleted successfully and removed from the cluster" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if !strings.Contains(deleteBody, `"preconditions":{"uid":"uid-web-config"}`) {
+				t.Errorf("expected UID precondition, got %s", deleteBody)
+			}
+		})
+		t.Run("resources_delete with labelSelector deletes the collection and waits for the removal", func(t *testing.T) {
+			deleteRequests = nil
+			clear(deletedConfigMaps)
+			toolResult, err := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "labelSelector": "app=web", "wait": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The following 2 ConfigMap resources have been deleted and removed from the cluster\n" +
+				"- web-config\n" +
+				"- web-env"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			// The collection is deleted at the listed resource version
+			if len(deleteRequests) != 1 || deleteRequests[0] != "/api/v1/namespaces/default/configmaps?labelSelector=app%3Dweb&resourceVersion=1&resourceVersionMatch=Exact" {
+				t.Errorf("expected a single DeleteCollection request, got %v", deleteRequests)
+			}
+		})
+		t.Run("resources_delete with labelSelector and no matches", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "labelSelector": "app=none"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "No ConfigMap resources found matching label selector app=none" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_delete with labelSelector falls back to single deletions and reports blocking finalizers", func(t *testing.T) {
+			deleteRequests = nil
+			toolResult, err := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "labelSelector": "team=old", "wait": true, "timeout": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The following 1 Namespace resources have been deleted\n" +
+				"- ns-stuck\n" +
+				"# Timed out after 1s waiting for the following 1 resources to be removed, they are still terminating\n" +
+				"- ns-stuck (terminating for 120m)\n" +
+				"  - spec finalizers: kubernetes\n" +
+				"  - NamespaceContentRemaining: Some resources are remaining: widgets.example.com has 1 resource instances\n" +
+				"  - NamespaceFinalizersRemaining: Some content in the namespace has finalizers remaining: example.com/cleanup in 1 resource instances"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if len(deleteRequests) != 2 || !strings.HasPrefix(deleteRequests[1], "/api/v1/namespaces/ns-stuck?") {
+				t.Errorf("expected DeleteCollection and a single deletion, got %v", deleteRequests)
+			}
+		})
+	})
+}
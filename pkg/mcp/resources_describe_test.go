@@ -0,0 +1,160 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+func TestResourcesDescribe(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list"}},
+				{Name: "events", Namespaced: true, Kind: "Event", Verbs: []string{"get", "list"}},
+			}},
+			&metav1.APIResourceList{GroupVersion: "example.com/v1", APIResources: []metav1.APIResource{
+				{Name: "widgets", SingularName: "widget", Namespaced: true, Kind: "Widget", Verbs: []string{"get", "list"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/api/v1/namespaces/default/configmaps/settings":
+				test.WriteObject(w, &v1.ConfigMap{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "settings", UID: "uid-settings"},
+					Data:       map[string]string{"log-level": "debug"},
+				})
+			case "/apis/example.com/v1/namespaces/default/widgets/gadget":
+				test.WriteObject(w, &unstructured.Unstructured{Object: map[string]interface{}{
+					"apiVersion": "example.com/v1",
+					"kind":       "Widget",
+					"metadata":   map[string]interface{}{"name": "gadget", "namespace": "default"},
+					"spec":       map[string]interface{}{"size": "large"},
+				}})
+			case "/api/v1/namespaces/default/events":
+				test.WriteObject(w, &v1.EventList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EventList"}, Items: []v1.Event{{
+					ObjectMeta:     metav1.ObjectMeta{Name: "event-1", Namespace: "default"},
+					InvolvedObject: v1.ObjectReference{Kind: "ConfigMap", Name: "settings", UID: "uid-settings"},
+					Type:           v1.EventTypeWarning,
+					Reason:         "Rejected",
+					Message:        "invalid log level",
+					Source:         v1.EventSource{Component: "operator"},
+				}}})
+			}
+		}))
+		t.Run("resources_describe with missing name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to describe resource, missing argument name" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_describe returns kubectl describe output with events", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "settings"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{"Name:         settings", "log-level:", "debug", "Events:", "Rejected", "invalid log level"} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q, got %v", expected, text)
+				}
+			}
+		})
+		t.Run("resources_describe describes custom resources with the generic describer", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "example.com/v1", "kind": "Widget", "namespace": "default", "name": "gadget"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{"Name:         gadget", "Kind:         Widget", "Size:  large"} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q, got %v", expected, text)
+				}
+			}
+		})
+	})
+}
+
+func TestResourcesDescribeDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{
+		{Version: "v1", Kind: "Pod"},
+		{Version: "v1", Kind: "Event"},
+	}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var deniedRequests []string
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "nodes", Kind: "Node", Verbs: []string{"get", "list"}},
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+				{Name: "events", Namespaced: true, Kind: "Event", Verbs: []string{"get", "list"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch req.URL.Path {
+			case "/api/v1/nodes/node-1":
+				test.WriteObject(w, &v1.Node{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+					ObjectMeta: metav1.ObjectMeta{Name: "node-1", UID: "uid-node-1"},
+				})
+			case "/api/v1/pods":
+				deniedRequests = append(deniedRequests, req.URL.Path)
+				test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: []v1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{Name: "leaked-pod", Namespace: "default"},
+					Spec:       v1.PodSpec{NodeName: "node-1"},
+				}}})
+			case "/api/v1/events":
+				deniedRequests = append(deniedRequests, req.URL.Path)
+				test.WriteObject(w, &v1.EventList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "EventList"}, Items: []v1.Event{{
+					ObjectMeta:     metav1.ObjectMeta{Name: "event-1", Namespace: "default"},
+					InvolvedObject: v1.ObjectReference{Kind: "Node", Name: "node-1", UID: "uid-node-1"},
+					Reason:         "Leaked",
+					Message:        "leaked event",
+				}}})
+			}
+		}))
+		t.Run("resources_describe with denied related kinds doesn't retrieve them", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "v1", "kind": "Node", "name": "node-1"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "Name:               node-1") {
+				t.Errorf("expected node description, got %v", text)
+			}
+			for _, leaked := range []string{"leaked-pod", "Leaked", "leaked event"} {
+				if strings.Contains(text, leaked) {
+					t.Errorf("unexpected denied resource %q, got %v", leaked, text)
+				}
+			}
+			if len(deniedRequests) > 0 {
+				t.Errorf("unexpected requests for denied resources %v", deniedRequests)
+			}
+		})
+		t.Run("resources_describe with denied kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_describe", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "leaked-pod"})
+			if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod") {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
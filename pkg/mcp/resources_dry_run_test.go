@@ -0,0 +1,156 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+func TestResourcesCreateOrUpdateDryRun(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var dryRuns []string
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list", "patch"}},
+				{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: []string{"get", "list", "patch"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v1/namespaces/default/configmaps/existing":
+				test.WriteObject(w, &v1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing", ResourceVersion: "1",
+						ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}}},
+					Data: map[string]string{"key": "value", "log-level": "info"},
+				})
+			case req.Method == http.MethodGet && req.URL.Path == "/api/v1/namespaces/default/secrets/credentials":
+				test.WriteObject(w, &v1.Secret{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "credentials", ResourceVersion: "1"},
+					Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("old-password")},
+				})
+			case req.Method == http.MethodPatch && req.URL.Path == "/api/v1/namespaces/default/secrets/credentials":
+				body, _ := io.ReadAll(req.Body)
+				applied := &v1.Secret{}
+				_ = yaml.Unmarshal(body, applied)
+				applied.ResourceVersion = "1"
+				test.WriteObject(w, applied)
+			case req.Method == http.MethodGet:
+				w.WriteHeader(http.StatusNotFound)
+				test.WriteObject(w, &apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "new").ErrStatus)
+			case req.Method == http.MethodPatch:
+				dryRuns = append(dryRuns, req.URL.Query().Get("dryRun"))
+				body, _ := io.ReadAll(req.Body)
+				applied := &v1.ConfigMap{}
+				_ = yaml.Unmarshal(body, applied)
+				applied.ResourceVersion = "1"
+				if applied.Name == "existing" {
+					applied.Data["key"] = "value"
+				}
+				test.WriteObject(w, applied)
+			}
+		}))
+		resource := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: existing\n  namespace: default\ndata:\n  log-level: debug\n" +
+			"---\n" +
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: new\n  namespace: default\ndata:\n  key: value\n"
+		t.Run("resources_create_or_update with diff returns unified diff", func(t *testing.T) {
+			dryRuns = nil
+			toolResult, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": resource, "diff": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The following changes (unified diff) would be applied to the resources (dry run)\n" +
+				"--- live/v1/ConfigMap/default/existing\n" +
+				"+++ dry-run/v1/ConfigMap/default/existing\n" +
+				"@@ -1,7 +1,7 @@\n" +
+				" apiVersion: v1\n" +
+				" data:\n" +
+				"   key: value\n" +
+				"-  log-level: info\n" +
+				"+  log-level: debug\n" +
+				" kind: ConfigMap\n" +
+				" metadata:\n" +
+				"   creationTimestamp: null\n" +
+				"--- live/v1/ConfigMap/default/new\n" +
+				"+++ dry-run/v1/ConfigMap/default/new\n" +
+				"@@ -0,0 +1,9 @@\n" +
+				"+apiVersion: v1\n"
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, expected) {
+				t.Errorf("unexpected diff, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "managedFields") {
+				t.Errorf("diff should ignore managedFields, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if strings.Join(dryRuns, ",") != "All,All" {
+				t.Errorf("expected server-side dry-run for every document, got %v", dryRuns)
+			}
+		})
+		t.Run("resources_create_or_update with diff masks Secret values", func(t *testing.T) {
+			secret := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: credentials\n  namespace: default\n" +
+				"data:\n  username: YWRtaW4=\n" +
+				"stringData:\n  password: new-password\n  token: new-token\n"
+			toolResult, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": secret, "diff": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "# The following changes (unified diff) would be applied to the resources (dry run)\n" +
+				"--- live/v1/Secret/default/credentials\n" +
+				"+++ dry-run/v1/Secret/default/credentials\n" +
+				"@@ -1,6 +1,7 @@\n" +
+				" apiVersion: v1\n" +
+				" data:\n" +
+				"-  password: '*** (before)'\n" +
+				"+  password: '*** (after)'\n" +
+				"+  token: '***'\n" +
+				"   username: '***'\n" +
+				" kind: Secret\n" +
+				" metadata:\n"
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, expected) {
+				t.Errorf("unexpected diff, got %v", text)
+			}
+			for _, value := range []string{"YWRtaW4=", "b2xkLXBhc3N3b3Jk", "new-password", "new-token", "stringData"} {
+				if strings.Contains(text, value) {
+					t.Errorf("diff should mask the Secret values (%s), got %v", value, text)
+				}
+			}
+		})
+		t.Run("resources_create_or_update with dryRun returns dry-run result", func(t *testing.T) {
+			dryRuns = nil
+			toolResult, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": resource, "dryRun": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The following resources (YAML) would be created or updated (dry run)\n") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if strings.Join(dryRuns, ",") != "All,All" {
+				t.Errorf("expected server-side dry-run for every document, got %v", dryRuns)
+			}
+		})
+		t.Run("resources_create_or_update without dryRun applies resources", func(t *testing.T) {
+			dryRuns = nil
+			toolResult, err := c.callTool("resources_create_or_update", map[string]interface{}{"resource": resource})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if strings.Join(dryRuns, ",") != "," {
+				t.Errorf("expected no dry-run, got %v", dryRuns)
+			}
+		})
+	})
+}
@@ -0,0 +1,295 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func listOptionsMockServer(t *testing.T) *test.MockServer {
+	mockServer := test.NewMockServer()
+	mockServer.Handle(test.NewDiscoveryClientHandler(
+		&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+		}},
+	))
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(req.URL.Path, "/pods") {
+			return
+		}
+		query := req.URL.Query()
+		if query.Get("fieldSelector") != "status.phase=Running" {
+			t.Errorf("unexpected field selector %s", query.Get("fieldSelector"))
+		}
+		listMeta := metav1.ListMeta{ResourceVersion: "1"}
+		name := "running-1"
+		if query.Get("continue") == "page-2" {
+			name = "running-2"
+		} else if query.Get("limit") == "1" {
+			listMeta.Continue = "page-2"
+			listMeta.RemainingItemCount = ptr.To(int64(1))
+		}
+		if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+			test.WriteObject(w, &metav1.Table{
+				TypeMeta:          metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+				ListMeta:          listMeta,
+				ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string"}},
+				Rows:              []metav1.TableRow{{Cells: []interface{}{name}, Object: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"` + name + `","namespace":"default"}}`)}}},
+			})
+			return
+		}
+		test.WriteObject(w, &v1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			ListMeta: listMeta,
+			Items:    []v1.Pod{{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}},
+		})
+	}))
+	return mockServer
+}
+
+func TestResourcesListOptions(t *testing.T) {
+	mockServer := listOptionsMockServer(t)
+	defer mockServer.Close()
+	testCase(t, func(c *mcpContext) {
+		c.withKubeConfig(mockServer.Config())
+		for _, tool := range []string{"resources_list", "pods_list", "pods_list_in_namespace"} {
+			arguments := map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "fieldSelector": "status.phase=Running"}
+			t.Run(tool+" with limit returns continue token", func(t *testing.T) {
+				arguments["limit"] = 1
+				toolResult, err := c.callTool(tool, arguments)
+				if err != nil || toolResult.IsError {
+					t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+				}
+				text := toolResult.Content[0].(mcp.TextContent).Text
+				if !strings.HasPrefix(text, "# The list is truncated (1 remaining), use the continue token page-2 to retrieve the next page\n") {
+					t.Errorf("expected continue token, got %v", text)
+				}
+				if !strings.Contains(text, "name: running-1") {
+					t.Errorf("expected first page, got %v", text)
+				}
+			})
+			t.Run(tool+" with continue returns next page", func(t *testing.T) {
+				arguments["continue"] = "page-2"
+				toolResult, err := c.callTool(tool, arguments)
+				if err != nil || toolResult.IsError {
+					t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+				}
+				text := toolResult.Content[0].(mcp.TextContent).Text
+				if strings.HasPrefix(text, "#") || !strings.Contains(text, "name: running-2") {
+					t.Errorf("expected last page without continue token, got %v", text)
+				}
+			})
+		}
+		t.Run("resources_list with invalid limit returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "limit": 0})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "limit must be a number greater than 0" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestResourcesListOptionsAsTable(t *testing.T) {
+	mockServer := listOptionsMockServer(t)
+	defer mockServer.Close()
+	testCaseWithContext(t, &mcpContext{listOutput: output.Table}, func(c *mcpContext) {
+		c.withKubeConfig(mockServer.Config())
+		toolResult, err := c.callTool("pods_list_in_namespace", map[string]interface{}{"namespace": "default", "fieldSelector": "status.phase=Running", "limit": 1})
+		t.Run("pods_list_in_namespace with limit returns continue token", func(t *testing.T) {
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "# The list is truncated (1 remaining), use the continue token page-2 to retrieve the next page\n") {
+				t.Errorf("expected continue token, got %v", text)
+			}
+			if !strings.Contains(text, "running-1") {
+				t.Errorf("expected first page, got %v", text)
+			}
+		})
+	})
+}
+
+func TestResourcesProjection(t *testing.T) {
+	mockServer := test.NewMockServer()
+	defer mockServer.Close()
+	var accept string
+	mockServer.Handle(test.NewDiscoveryClientHandler(
+		&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+		}},
+	))
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		pod := func(name, image string) v1.Pod {
+			return v1.Pod{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: image}}},
+			}
+		}
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			accept = req.Header.Get("Accept")
+			test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: []v1.Pod{pod("web-1", "web:1"), pod("web-2", "web:2")}})
+		case "/api/v1/namespaces/default/pods/web-1":
+			p := pod("web-1", "web:1")
+			test.WriteObject(w, &p)
+		}
+	}))
+	testCaseWithContext(t, &mcpContext{listOutput: output.Table}, func(c *mcpContext) {
+		c.withKubeConfig(mockServer.Config())
+		t.Run("resources_get with jsonpath returns only the requested fields", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_get", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "web-1", "jsonpath": ".spec.containers[*].image",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "web:1" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_list with jsonpath returns only the requested fields", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_list", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Pod", "namespace": "default", "jsonpath": `{range .items[*]}{.metadata.name}={.spec.containers[0].image}{"\n"}{end}`,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "web-1=web:1\nweb-2=web:2\n" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if strings.Contains(accept, "as=Table") {
+				t.Errorf("jsonpath should be evaluated against the complete resources, got Accept %s", accept)
+			}
+		})
+		t.Run("resources_list with customColumns returns table with the requested columns", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_list", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Pod", "namespace": "default", "customColumns": "NAME:.metadata.name,IMAGE:.spec.containers[*].image",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "NAME      IMAGE\nweb-1     web:1\nweb-2     web:2\n" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_list with jsonpath and customColumns returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Pod", "jsonpath": ".items", "customColumns": "NAME:.metadata.name",
+			})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to list resources, jsonpath can't be combined with customColumns" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func TestResourcesListMulti(t *testing.T) {
+	mockServer := test.NewMockServer()
+	defer mockServer.Close()
+	var listed []string
+	mockServer.Handle(test.NewDiscoveryClientHandler(
+		&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}, Categories: []string{"all"}},
+			{Name: "pods/log", Namespaced: true, Kind: "Pod", Verbs: []string{"get"}},
+			{Name: "services", Namespaced: true, Kind: "Service", Verbs: []string{"get", "list"}, Categories: []string{"all"}},
+			{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list"}},
+		}},
+		&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list"}, Categories: []string{"all"}},
+		}},
+	))
+	mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/default/") && !strings.HasPrefix(req.URL.Path, "/apis/apps/v1/namespaces/default/") {
+			return
+		}
+		listed = append(listed, req.URL.Path)
+		if req.URL.Query().Get("labelSelector") != "app=web" {
+			t.Errorf("unexpected label selector %s", req.URL.Query().Get("labelSelector"))
+		}
+		table := &metav1.Table{
+			TypeMeta:          metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+			ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string"}},
+		}
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/pods"):
+			table.Rows = []metav1.TableRow{{Cells: []interface{}{"web-1"}, Object: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web-1","namespace":"default"}}`)}}}
+		case strings.HasSuffix(req.URL.Path, "/deployments"):
+			table.Rows = []metav1.TableRow{{Cells: []interface{}{"web"}, Object: runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default"}}`)}}}
+		case strings.HasSuffix(req.URL.Path, "/configmaps"):
+			w.WriteHeader(http.StatusForbidden)
+			test.WriteObject(w, &metav1.Status{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}, Status: metav1.StatusFailure,
+				Code: http.StatusForbidden, Reason: metav1.StatusReasonForbidden, Message: "configmaps is forbidden"})
+			return
+		}
+		test.WriteObject(w, table)
+	}))
+	testCase(t, func(c *mcpContext) {
+		c.withKubeConfig(mockServer.Config())
+		t.Run("resources_list_multi with missing kinds and category returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list_multi", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to list resources, missing argument kinds or category" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_list_multi with invalid kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_list_multi", map[string]interface{}{"kinds": []interface{}{"Deployment"}})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to list resources, invalid kind Deployment, expected apiVersion/kind (e.g. apps/v1/Deployment)" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_list_multi with category lists the kinds in the category", func(t *testing.T) {
+			listed = nil
+			toolResult, err := c.callTool("resources_list_multi", map[string]interface{}{"category": "all", "namespace": "default", "labelSelector": "app=web"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{"# v1/Pod\n", "web-1", "# apps/v1/Deployment\n", "apps/v1      Deployment   web", "# No resources found for v1/Service\n"} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q, got %v", expected, text)
+				}
+			}
+			if len(listed) != 3 || strings.Contains(text, "ConfigMap") {
+				t.Errorf("expected only the kinds in the category to be listed, got %v", listed)
+			}
+		})
+		t.Run("resources_list_multi with kinds reports failures per kind", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_list_multi", map[string]interface{}{
+				"kinds": []interface{}{"v1/ConfigMap", "v1/Pod"}, "namespace": "default", "labelSelector": "app=web",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "# Failed to list v1/ConfigMap: configmaps is forbidden\n# v1/Pod\n") {
+				t.Errorf("unexpected result, got %v", text)
+			}
+		})
+	})
+}
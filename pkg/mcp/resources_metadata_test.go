@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourcesLabelAndAnnotate(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		deployment := func(name string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name,
+					Labels:      map[string]string{"app": "web", "team": "payments"},
+					Annotations: map[string]string{"example.com/owner": "payments-team"},
+				},
+			}
+		}
+		var patched []string
+		var patchContentType, patchBody, listSelector string
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+				{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list", "patch"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case req.Method == http.MethodGet && req.URL.Path == "/apis/apps/v1/namespaces/default/deployments":
+				listSelector = req.URL.Query().Get("labelSelector")
+				test.WriteObject(w, &appsv1.DeploymentList{
+					TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DeploymentList"},
+					Items:    []appsv1.Deployment{*deployment("web"), *deployment("web-canary")},
+				})
+			case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, "/apis/apps/v1/namespaces/default/deployments/"):
+				test.WriteObject(w, deployment(path.Base(req.URL.Path)))
+			case req.Method == http.MethodPatch:
+				body, _ := io.ReadAll(req.Body)
+				patchContentType, patchBody = req.Header.Get("Content-Type"), string(body)
+				patched = append(patched, path.Base(req.URL.Path))
+				// Minimal merge patch of the labels and annotations
+				patch := map[string]map[string]map[string]*string{}
+				_ = json.Unmarshal(body, &patch)
+				obj := deployment(path.Base(req.URL.Path))
+				for field, current := range map[string]map[string]string{"labels": obj.Labels, "annotations": obj.Annotations} {
+					for key, value := range patch["metadata"][field] {
+						if value == nil {
+							delete(current, key)
+						} else {
+							current[key] = *value
+						}
+					}
+				}
+				test.WriteObject(w, obj)
+			}
+		}))
+		t.Run("resources_label with missing name and labelSelector returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_label", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "labels": map[string]interface{}{"tier": "frontend"},
+			})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to label resources, missing argument name or labelSelector" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_annotate with missing annotations and remove returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_annotate", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to annotate resources, missing argument annotations or remove" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_label with invalid label value returns error", func(t *testing.T) {
+			patched = nil
+			toolResult, _ := c.callTool("resources_label", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "labels": map[string]interface{}{"tier": "front end"},
+			})
+			if !toolResult.IsError || !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to label resources: invalid label value tier=front end") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if len(patched) > 0 {
+				t.Errorf("no resource should be patched, got %v", patched)
+			}
+		})
+		t.Run("resources_label with existing different value and no overwrite returns error", func(t *testing.T) {
+			patched = nil
+			toolResult, _ := c.callTool("resources_label", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "labels": map[string]interface{}{"team": "checkout"},
+			})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text !=
+				"failed to label resources: Deployment/web already has a value (payments) for the key team, and overwrite is false" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if len(patched) > 0 {
+				t.Errorf("no resource should be patched, got %v", patched)
+			}
+		})
+		t.Run("resources_label with name adds, overwrites and removes labels", func(t *testing.T) {
+			patched = nil
+			toolResult, err := c.callTool("resources_label", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "name": "web",
+				"labels":    map[string]interface{}{"team": "checkout", "tier": "frontend"},
+				"remove":    []interface{}{"app"},
+				"overwrite": true,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if patchContentType != "application/merge-patch+json" {
+				t.Errorf("expected merge patch, got %s", patchContentType)
+			}
+			if patchBody != `{"metadata":{"labels":{"app":null,"team":"checkout","tier":"frontend"}}}` {
+				t.Errorf("unexpected patch, got %s", patchBody)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			expected := "# The labels of the following resources (YAML) have been updated successfully\n" +
+				"- apiVersion: apps/v1\n" +
+				"  kind: Deployment\n" +
+				"  metadata:\n" +
+				"    labels:\n" +
+				"      team: checkout\n" +
+				"      tier: frontend\n" +
+				"    name: web\n" +
+				"    namespace: default\n"
+			if text != expected {
+				t.Errorf("unexpected result, got\n%v", text)
+			}
+		})
+		t.Run("resources_annotate with labelSelector annotates every matching resource", func(t *testing.T) {
+			patched = nil
+			toolResult, err := c.callTool("resources_annotate", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "labelSelector": "app=web",
+				// Same value as the existing one, no overwrite required
+				"annotations": map[string]interface{}{"example.com/owner": "payments-team", "example.com/cost-center": "1234"},
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if listSelector != "app=web" {
+				t.Errorf("expected labelSelector app=web, got %s", listSelector)
+			}
+			if strings.Join(patched, ",") != "web,web-canary" {
+				t.Errorf("expected web and web-canary to be patched, got %v", patched)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, "    name: web-canary\n") || !strings.Contains(text, "      example.com/cost-center: \"1234\"\n") {
+				t.Errorf("unexpected result, got\n%v", text)
+			}
+		})
+	})
+}
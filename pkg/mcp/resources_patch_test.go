@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourcesPatch(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var patchPath, patchContentType, patchBody, patchFieldManager string
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+				{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list", "patch"}},
+				{Name: "deployments/scale", Namespaced: true, Group: "autoscaling", Version: "v1", Kind: "Scale", Verbs: []string{"get", "patch"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if req.Method != http.MethodPatch {
+				return
+			}
+			body, _ := io.ReadAll(req.Body)
+			patchPath, patchContentType, patchBody = req.URL.Path, req.Header.Get("Content-Type"), string(body)
+			patchFieldManager = req.URL.Query().Get("fieldManager")
+			switch req.URL.Path {
+			case "/apis/apps/v1/namespaces/default/deployments/web":
+				test.WriteObject(w, &appsv1.Deployment{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Labels: map[string]string{"tier": "frontend"}},
+				})
+			case "/apis/apps/v1/namespaces/default/deployments/web/scale":
+				test.WriteObject(w, &autoscalingv1.Scale{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v1", Kind: "Scale"},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+					Spec:       autoscalingv1.ScaleSpec{Replicas: 3},
+				})
+			}
+		}))
+		t.Run("resources_patch with missing patch returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to patch resource, missing argument patch" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_patch with invalid patchType returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_patch", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "patch": "{}", "patchType": "apply"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to patch resource, invalid patchType apply" {
+				t.Errorf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_patch with defaults applies strategic merge patch", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_patch", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "web",
+				"patch": `{"metadata":{"labels":{"tier":"frontend"}}}`,
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if patchContentType != "application/strategic-merge-patch+json" {
+				t.Errorf("unexpected patch content type %s", patchContentType)
+			}
+			if patchBody != `{"metadata":{"labels":{"tier":"frontend"}}}` {
+				t.Errorf("unexpected patch body %s", patchBody)
+			}
+			if patchFieldManager != "kubernetes-mcp-server" {
+				t.Errorf("unexpected field manager %s", patchFieldManager)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The following resource (YAML) has been patched successfully\n") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_patch with json patch in YAML format sends JSON", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_patch", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "web", "patchType": "json",
+				"patch": "- op: remove\n  path: /metadata/labels/obsolete\n",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if patchContentType != "application/json-patch+json" {
+				t.Errorf("unexpected patch content type %s", patchContentType)
+			}
+			if patchBody != `[{"op":"remove","path":"/metadata/labels/obsolete"}]` {
+				t.Errorf("unexpected patch body %s", patchBody)
+			}
+		})
+		t.Run("resources_patch with merge patch and scale subresource patches the subresource", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_patch", map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "web", "patchType": "merge",
+				"patch": `{"spec":{"replicas":3}}`, "subresource": "scale",
+			})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if patchPath != "/apis/apps/v1/namespaces/default/deployments/web/scale" {
+				t.Errorf("unexpected patch path %s", patchPath)
+			}
+			if patchContentType != "application/merge-patch+json" {
+				t.Errorf("unexpected patch content type %s", patchContentType)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "replicas: 3") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
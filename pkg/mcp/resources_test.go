@@ -674,13 +674,13 @@ func TestResourcesDelete(t *testing.T) {
 				return
 			}
 		})
-		t.Run("resources_delete with missing name returns error", func(t *testing.T) {
+		t.Run("resources_delete with missing name and labelSelector returns error", func(t *testing.T) {
 			toolResult, _ := c.callTool("resources_delete", map[string]interface{}{"apiVersion": "v1", "kind": "Namespace"})
 			if !toolResult.IsError {
 				t.Fatalf("call tool should fail")
 				return
 			}
-			if toolResult.Content[0].(mcp.TextContent).Text != "failed to delete resource, missing argument name" {
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to delete resource, missing argument name or labelSelector" {
 				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
 				return
 			}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestResourcesTree(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var requested []string
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+				{Name: "pods/log", Namespaced: true, Kind: "Pod", Verbs: []string{"get"}},
+				{Name: "events", Namespaced: true, Kind: "Event", Verbs: []string{"get", "list"}},
+				{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: []string{"get", "list"}},
+				{Name: "nodes", Kind: "Node", Verbs: []string{"get", "list"}},
+			}},
+			&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+				{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list"}},
+				{Name: "replicasets", Namespaced: true, Kind: "ReplicaSet", Verbs: []string{"get", "list"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			requested = append(requested, req.URL.Path)
+			owner := func(kind, name string) []metav1.OwnerReference {
+				return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, UID: types.UID("uid-" + name), Controller: ptr.To(true)}}
+			}
+			web := appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web"},
+				Status:     appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue, Reason: "MinimumReplicasAvailable"}}},
+			}
+			switch req.URL.Path {
+			case "/apis/apps/v1/namespaces/default/deployments/web":
+				test.WriteObject(w, &web)
+			case "/apis/apps/v1/namespaces/default/deployments":
+				test.WriteObject(w, &appsv1.DeploymentList{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DeploymentList"}, Items: []appsv1.Deployment{web}})
+			case "/apis/apps/v1/namespaces/default/replicasets":
+				test.WriteObject(w, &appsv1.ReplicaSetList{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSetList"}, Items: []appsv1.ReplicaSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: "uid-web-1", OwnerReferences: owner("Deployment", "web")},
+						Spec:       appsv1.ReplicaSetSpec{Replicas: ptr.To(int32(2))},
+						Status:     appsv1.ReplicaSetStatus{ReadyReplicas: 1},
+					},
+					{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default", UID: "uid-unrelated"}},
+				}})
+			case "/api/v1/namespaces/default/pods":
+				test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: []v1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "web-1-b", Namespace: "default", UID: "uid-web-1-b", OwnerReferences: owner("ReplicaSet", "web-1")},
+						Status:     v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse, Reason: "ContainersNotReady"}}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "web-1-a", Namespace: "default", UID: "uid-web-1-a", OwnerReferences: owner("ReplicaSet", "web-1")},
+						Status:     v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+					},
+				}})
+			case "/api/v1/namespaces/default/secrets":
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"secrets is forbidden","reason":"Forbidden","code":403}`))
+			}
+		}))
+		t.Run("resources_tree with missing name returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_tree", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to get resource tree, missing argument name" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_tree returns the owned resources", func(t *testing.T) {
+			requested = nil
+			toolResult, err := c.callTool("resources_tree", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			for _, expected := range []string{
+				"# Failed to list v1/Secret, the tree may be incomplete: secrets is forbidden\n",
+				"NAMESPACE   NAME                 READY     REASON                     AGE\n",
+				"default     Deployment/web       True      MinimumReplicasAvailable   <unknown>\n",
+				"default     └─ReplicaSet/web-1   False     1/2 replicas ready         <unknown>\n",
+				"default       ├─Pod/web-1-a      True                                 <unknown>\n",
+				"default       └─Pod/web-1-b      False     ContainersNotReady         <unknown>\n",
+			} {
+				if !strings.Contains(text, expected) {
+					t.Errorf("expected %q, got\n%v", expected, text)
+				}
+			}
+			if strings.Contains(text, "unrelated") {
+				t.Errorf("unexpected unrelated resource, got %v", text)
+			}
+			for _, path := range requested {
+				if strings.HasSuffix(path, "/events") || strings.HasSuffix(path, "/nodes") {
+					t.Errorf("unexpected request %s", path)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func waitDeployment(name string, available v1.ConditionStatus, readyReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Generation: 1, ResourceVersion: "1", Labels: map[string]string{"app": name}},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 1, ReadyReplicas: readyReplicas, Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: available},
+		}},
+	}
+}
+
+func waitDeploymentWithUID(name string, uid types.UID) *appsv1.Deployment {
+	deployment := waitDeployment(name, v1.ConditionTrue, 3)
+	deployment.UID = uid
+	return deployment
+}
+
+func TestResourcesWait(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1"},
+			&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+				{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list", "watch"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if req.URL.Path != "/apis/apps/v1/namespaces/default/deployments" {
+				return
+			}
+			selector := req.URL.Query().Get("fieldSelector") + req.URL.Query().Get("labelSelector")
+			if req.URL.Query().Get("watch") != "true" {
+				list := &appsv1.DeploymentList{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DeploymentList"}, ListMeta: metav1.ListMeta{ResourceVersion: "1"}}
+				switch selector {
+				case "metadata.name=web":
+					list.Items = append(list.Items, *waitDeployment("web", v1.ConditionFalse, 0))
+				case "metadata.name=ready":
+					list.Items = append(list.Items, *waitDeployment("ready", v1.ConditionTrue, 3))
+				case "metadata.name=stuck":
+					list.Items = append(list.Items, *waitDeployment("stuck", v1.ConditionFalse, 0))
+				case "app=gone":
+					list.Items = append(list.Items, *waitDeployment("gone", v1.ConditionTrue, 3))
+				case "app=recreated":
+					list.Items = append(list.Items, *waitDeploymentWithUID("recreated", "uid-original"))
+				}
+				test.WriteObject(w, list)
+				return
+			}
+			switch selector {
+			case "metadata.name=web":
+				_ = json.NewEncoder(w).Encode(&metav1.WatchEvent{Type: "MODIFIED", Object: runtime.RawExtension{Object: waitDeployment("web", v1.ConditionTrue, 3)}})
+			case "app=gone":
+				_ = json.NewEncoder(w).Encode(&metav1.WatchEvent{Type: "DELETED", Object: runtime.RawExtension{Object: waitDeployment("gone", v1.ConditionTrue, 3)}})
+			case "app=recreated":
+				// The DELETED event was missed, the resource was recreated with the same name
+				_ = json.NewEncoder(w).Encode(&metav1.WatchEvent{Type: "ADDED", Object: runtime.RawExtension{Object: waitDeploymentWithUID("recreated", "uid-new")}})
+			default:
+				w.WriteHeader(http.StatusOK)
+				w.(http.Flusher).Flush()
+				<-req.Context().Done()
+			}
+		}))
+		t.Run("resources_wait with missing name and labelSelector returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "for": "delete"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to wait for resources, missing argument name or labelSelector" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with invalid condition returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "for": "available"})
+			if !toolResult.IsError || !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "failed to wait for resources: invalid wait condition available") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with met condition returns immediately", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "ready", "for": "condition=Available"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The condition condition=Available is met by all the resources\n# Final state of the resources (YAML)\n") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with condition waits for the watch event", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "for": "condition=available=true"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "# The condition condition=available=true is met by all the resources\n") || !strings.Contains(text, "readyReplicas: 3") {
+				t.Errorf("unexpected result, got %v", text)
+			}
+		})
+		t.Run("resources_wait with jsonpath waits for the watch event", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "for": "jsonpath={.status.readyReplicas}=3"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "# The condition jsonpath={.status.readyReplicas}=3 is met by all the resources\n") {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with delete waits for the deletion", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "labelSelector": "app=gone", "for": "delete"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "# The condition delete is met by all the resources\n" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with delete and recreated resource (different UID) is met", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "labelSelector": "app=recreated", "for": "delete", "timeout": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "# The condition delete is met by all the resources\n" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with delete and missing resource returns immediately", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "missing", "for": "delete"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "No Deployment resources found, the condition delete is met" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with condition and missing resource returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "missing", "for": "condition=Available"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to wait for resources: deployments.apps \"missing\" not found" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("resources_wait with timeout returns the final state", func(t *testing.T) {
+			toolResult, err := c.callTool("resources_wait", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "stuck", "for": "condition=Available", "timeout": 1})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			text := toolResult.Content[0].(mcp.TextContent).Text
+			if !strings.HasPrefix(text, "# Timed out after 1s waiting for the condition condition=Available, not met by: stuck\n") || !strings.Contains(text, "name: stuck") {
+				t.Errorf("unexpected result, got %v", text)
+			}
+		})
+	})
+}
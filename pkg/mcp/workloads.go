@@ -0,0 +1,280 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// rolloutStatusMaxTimeout is the maximum number of seconds rollout_status can wait for a rollout to complete
+const rolloutStatusMaxTimeout = 300
+
+func (s *Server) initWorkloads() []server.ServerTool {
+	var workloadKinds []string
+	for _, gvk := range kubernetes.WorkloadKinds {
+		workloadKinds = append(workloadKinds, gvk.Kind)
+	}
+	var rolloutKinds []string
+	for _, gvk := range kubernetes.RolloutKinds {
+		rolloutKinds = append(rolloutKinds, gvk.Kind)
+	}
+	return []server.ServerTool{
+		{Tool: mcp.NewTool("workload_logs",
+			mcp.WithDescription("Get the logs of all the containers of all the Pods of a workload ("+strings.Join(workloadKinds, ", ")+") or matching a label selector in the current or provided namespace. "+
+				"Log lines are interleaved by timestamp and prefixed with the pod/container they belong to"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload or Pods to get the logs from (Optional, current namespace if not provided)")),
+			mcp.WithString("kind", mcp.Description("Kind of the workload to get the logs from (one of: "+strings.Join(workloadKinds, ", ")+"). Required if labelSelector is not provided")),
+			mcp.WithString("name", mcp.Description("Name of the workload to get the logs from. Required if labelSelector is not provided")),
+			mcp.WithString("labelSelector", mcp.Description("Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)') to match the Pods to get the logs from. Required if kind and name are not provided"), mcp.Pattern("([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]")),
+			mcp.WithString("container", mcp.Description("Name of the container to get the logs from (Optional, all containers if not provided)")),
+			mcp.WithBoolean("previous", mcp.Description("If true, return the logs of the previous terminated container instances (Optional)")),
+			mcp.WithNumber("tail", mcp.Description(fmt.Sprintf("Number of lines to retrieve from the end of the logs of each container (Optional, defaults to %d, use -1 to retrieve all the lines)", kubernetes.DefaultPodsLogTailLines))),
+			mcp.WithNumber("sinceSeconds", mcp.Description("Only return logs newer than a relative duration in seconds (Optional, mutually exclusive with sinceTime)")),
+			mcp.WithString("sinceTime", mcp.Description("Only return logs after a specific date in RFC3339 format, e.g. 2025-01-01T10:00:00Z (Optional, mutually exclusive with sinceSeconds)")),
+			mcp.WithBoolean("timestamps", mcp.Description("If true, include the RFC3339 timestamp of every line in the log output (Optional)")),
+			mcp.WithNumber("limitBytes", mcp.Description("Maximum number of bytes of logs to return for each container (Optional)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Workloads: Logs"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.workloadLogs},
+		{Tool: mcp.NewTool("workload_scale",
+			mcp.WithDescription("Scale a workload (Deployment, StatefulSet) in the current or provided namespace to the provided number of replicas through its scale subresource"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload to scale (Optional, current namespace if not provided)")),
+			mcp.WithString("kind", mcp.Description("Kind of the workload to scale (one of: Deployment, StatefulSet)"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the workload to scale"), mcp.Required()),
+			mcp.WithNumber("replicas", mcp.Description("Desired number of replicas, use 0 to stop all the Pods of the workload"), mcp.Min(0), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Workloads: Scale"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true), // Scaling down terminates Pods
+			mcp.WithIdempotentHintAnnotation(true),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.workloadScale},
+		{Tool: mcp.NewTool("rollout_restart",
+			mcp.WithDescription("Restart the Pods of a workload ("+strings.Join(rolloutKinds, ", ")+") in the current or provided namespace with a rolling update (same as kubectl rollout restart)"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload to restart (Optional, current namespace if not provided)")),
+			mcp.WithString("kind", mcp.Description("Kind of the workload to restart (one of: "+strings.Join(rolloutKinds, ", ")+")"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the workload to restart"), mcp.Required()),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Restart"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true), // All the Pods of the workload are replaced
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutRestart},
+		{Tool: mcp.NewTool("rollout_status",
+			mcp.WithDescription("Get the rollout status of a workload ("+strings.Join(rolloutKinds, ", ")+") in the current or provided namespace (same as kubectl rollout status), "+
+				"optionally waiting for the rollout to complete"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			mcp.WithString("kind", mcp.Description("Kind of the workload (one of: "+strings.Join(rolloutKinds, ", ")+")"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithNumber("timeout", mcp.Description(fmt.Sprintf("Maximum number of seconds to wait for the rollout to complete (Optional, defaults to 0 to return the current status without waiting, max %d)", rolloutStatusMaxTimeout)),
+				mcp.Min(0), mcp.Max(rolloutStatusMaxTimeout)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Status"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutStatus},
+		{Tool: mcp.NewTool("rollout_history",
+			mcp.WithDescription("Get the rollout history (revisions) of a workload ("+strings.Join(rolloutKinds, ", ")+") in the current or provided namespace (same as kubectl rollout history), "+
+				"or the Pod template of a specific revision"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			mcp.WithString("kind", mcp.Description("Kind of the workload (one of: "+strings.Join(rolloutKinds, ", ")+")"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithNumber("revision", mcp.Description("Revision to get the Pod template of (Optional, lists all the revisions if not provided)"), mcp.Min(0)),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: History"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithDestructiveHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutHistory},
+		{Tool: mcp.NewTool("rollout_undo",
+			mcp.WithDescription("Roll back a workload ("+strings.Join(rolloutKinds, ", ")+") in the current or provided namespace to a previous revision (same as kubectl rollout undo). "+
+				"Use rollout_history to list the available revisions"),
+			mcp.WithString("namespace", mcp.Description("Namespace of the workload (Optional, current namespace if not provided)")),
+			mcp.WithString("kind", mcp.Description("Kind of the workload (one of: "+strings.Join(rolloutKinds, ", ")+")"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Name of the workload"), mcp.Required()),
+			mcp.WithNumber("toRevision", mcp.Description("Revision to roll back to (Optional, defaults to the previous revision)"), mcp.Min(0)),
+			mcp.WithBoolean("dryRun", mcp.Description("If true, return the Pod template of the target revision without rolling back (Optional)")),
+			// Tool annotations
+			mcp.WithTitleAnnotation("Rollout: Undo"),
+			mcp.WithReadOnlyHintAnnotation(false),
+			mcp.WithDestructiveHintAnnotation(true),
+			mcp.WithIdempotentHintAnnotation(false),
+			mcp.WithOpenWorldHintAnnotation(true),
+		), Handler: s.rolloutUndo},
+	}
+}
+
+func (s *Server) workloadLogs(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace := ""
+	if v, ok := ctr.GetArguments()["namespace"].(string); ok {
+		namespace = v
+	}
+	podsLogOptions, err := parsePodsLogOptions(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get workload logs, %v", err)), nil
+	}
+	workloadLogsOptions := kubernetes.WorkloadLogsOptions{PodsLogOptions: podsLogOptions}
+	if v, ok := ctr.GetArguments()["kind"].(string); ok {
+		workloadLogsOptions.Kind = v
+	}
+	if v, ok := ctr.GetArguments()["name"].(string); ok {
+		workloadLogsOptions.Name = v
+	}
+	if v, ok := ctr.GetArguments()["labelSelector"].(string); ok {
+		workloadLogsOptions.LabelSelector = v
+	}
+	if workloadLogsOptions.LabelSelector == "" && (workloadLogsOptions.Kind == "" || workloadLogsOptions.Name == "") {
+		return NewTextResult("", errors.New("failed to get workload logs, missing argument kind and name, or labelSelector")), nil
+	}
+	if workloadLogsOptions.LabelSelector != "" && (workloadLogsOptions.Kind != "" || workloadLogsOptions.Name != "") {
+		return NewTextResult("", errors.New("failed to get workload logs, kind and name can't be combined with labelSelector")), nil
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := derived.WorkloadsLog(ctx, namespace, workloadLogsOptions)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get workload logs in namespace %s: %v", namespace, err)), nil
+	} else if ret == "" {
+		ret = fmt.Sprintf("The matching pods in namespace %s have not logged any message yet", namespace)
+	}
+	return NewTextResult(ret, err), nil
+}
+
+func (s *Server) workloadScale(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, kind, name, err := parseWorkloadArguments(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to scale workload, %v", err)), nil
+	}
+	replicas, ok := ctr.GetArguments()["replicas"].(float64)
+	if !ok {
+		return NewTextResult("", errors.New("failed to scale workload, missing argument replicas")), nil
+	}
+	if replicas < 0 || replicas != float64(int32(replicas)) {
+		return NewTextResult("", fmt.Errorf("failed to scale workload, replicas must be a non-negative integer, got %v", replicas)), nil
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := derived.WorkloadsScale(ctx, namespace, kind, name, int32(replicas))
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to scale %s %s: %v", kind, name, err)), nil
+	}
+	return NewTextResult(output.MarshalYaml(scale)), nil
+}
+
+func (s *Server) rolloutRestart(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, kind, name, err := parseWorkloadArguments(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to restart workload, %v", err)), nil
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workload, err := derived.RolloutRestart(ctx, namespace, kind, name)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to restart %s %s: %v", kind, name, err)), nil
+	}
+	return NewTextResult(fmt.Sprintf("%s %s/%s restarted, use rollout_status to follow the progress of the rollout", workload.GetKind(), workload.GetNamespace(), workload.GetName()), nil), nil
+}
+
+func (s *Server) rolloutStatus(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, kind, name, err := parseWorkloadArguments(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout status, %v", err)), nil
+	}
+	timeout := 0
+	if v, ok := ctr.GetArguments()["timeout"].(float64); ok {
+		timeout = int(v)
+	}
+	if timeout < 0 || timeout > rolloutStatusMaxTimeout {
+		return NewTextResult("", fmt.Errorf("failed to get rollout status, timeout must be between 0 and %d seconds", rolloutStatusMaxTimeout)), nil
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, done, err := derived.RolloutStatus(ctx, namespace, kind, name, time.Duration(timeout)*time.Second)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout status of %s %s: %v", kind, name, err)), nil
+	}
+	if !done && timeout > 0 {
+		status = fmt.Sprintf("# The rollout didn't complete in %d seconds\n%s", timeout, status)
+	}
+	return NewTextResult(status, nil), nil
+}
+
+func (s *Server) rolloutHistory(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, kind, name, err := parseWorkloadArguments(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout history, %v", err)), nil
+	}
+	var revision int64
+	if v, ok := ctr.GetArguments()["revision"].(float64); ok {
+		revision = int64(v)
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	history, err := derived.RolloutHistory(ctx, namespace, kind, name, revision)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to get rollout history of %s %s: %v", kind, name, err)), nil
+	}
+	return NewTextResult(history, nil), nil
+}
+
+func (s *Server) rolloutUndo(ctx context.Context, ctr mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	namespace, kind, name, err := parseWorkloadArguments(ctr.GetArguments())
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to undo rollout, %v", err)), nil
+	}
+	var toRevision int64
+	if v, ok := ctr.GetArguments()["toRevision"].(float64); ok {
+		toRevision = int64(v)
+	}
+	dryRun := false
+	if v, ok := ctr.GetArguments()["dryRun"].(bool); ok {
+		dryRun = v
+	}
+	derived, err := s.k.Derived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := derived.RolloutUndo(ctx, namespace, kind, name, toRevision, dryRun)
+	if err != nil {
+		return NewTextResult("", fmt.Errorf("failed to undo rollout of %s %s: %v", kind, name, err)), nil
+	}
+	if dryRun {
+		return NewTextResult("# The following Pod template (YAML) would be rolled back to (dry run)\n"+ret, nil), nil
+	}
+	return NewTextResult(fmt.Sprintf("%s %s %s", kind, name, ret), nil), nil
+}
+
+// parseWorkloadArguments returns the namespace, kind and name arguments of the tools operating on a single workload
+func parseWorkloadArguments(arguments map[string]interface{}) (namespace, kind, name string, err error) {
+	namespace, _ = arguments["namespace"].(string)
+	if kind, _ = arguments["kind"].(string); kind == "" {
+		return "", "", "", errors.New("missing argument kind")
+	}
+	if name, _ = arguments["name"].(string); name == "" {
+		return "", "", "", errors.New("missing argument name")
+	}
+	return namespace, kind, name, nil
+}
@@ -0,0 +1,323 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestWorkloadLogs(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if req.URL.Path == "/api/v1/namespaces/default/pods" {
+				if req.URL.Query().Get("labelSelector") != "app=replicated" {
+					test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}})
+					return
+				}
+				test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: []v1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "replica-1", Namespace: "default"},
+						Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}, {Name: "sidecar"}}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "replica-2", Namespace: "default"},
+						Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app"}}},
+					},
+				}})
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			switch req.URL.Path + "?" + req.URL.Query().Get("container") {
+			case "/api/v1/namespaces/default/pods/replica-1/log?app":
+				_, _ = w.Write([]byte("2025-01-01T10:00:01Z first from replica-1\n2025-01-01T10:00:04Z fourth from replica-1\n"))
+			case "/api/v1/namespaces/default/pods/replica-1/log?sidecar":
+				_, _ = w.Write([]byte("2025-01-01T10:00:03Z third from sidecar\n"))
+			case "/api/v1/namespaces/default/pods/replica-2/log?app":
+				_, _ = w.Write([]byte("2025-01-01T10:00:02Z second from replica-2\n"))
+			}
+		}))
+		t.Run("workload_logs with missing arguments returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("workload_logs", map[string]interface{}{})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "failed to get workload logs, missing argument kind and name, or labelSelector" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("workload_logs with unsupported kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("workload_logs", map[string]interface{}{"kind": "ConfigMap", "name": "a-configmap"})
+			if !toolResult.IsError {
+				t.Fatalf("call tool should fail")
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "unsupported workload kind ConfigMap") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("workload_logs with label selector returns interleaved logs", func(t *testing.T) {
+			toolResult, err := c.callTool("workload_logs", map[string]interface{}{"labelSelector": "app=replicated"})
+			if err != nil {
+				t.Fatalf("call tool failed %v", err)
+			}
+			if toolResult.IsError {
+				t.Fatalf("call tool failed %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			expected := "[replica-1/app] first from replica-1\n" +
+				"[replica-2/app] second from replica-2\n" +
+				"[replica-1/sidecar] third from sidecar\n" +
+				"[replica-1/app] fourth from replica-1\n"
+			if toolResult.Content[0].(mcp.TextContent).Text != expected {
+				t.Errorf("unexpected logs, expected:\n%s\ngot:\n%s", expected, toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("workload_logs with timestamps keeps timestamps", func(t *testing.T) {
+			toolResult, _ := c.callTool("workload_logs", map[string]interface{}{"labelSelector": "app=replicated", "timestamps": true})
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "[replica-2/app] 2025-01-01T10:00:02Z second from replica-2\n") {
+				t.Errorf("expected timestamped line, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("workload_logs with container only returns matching containers", func(t *testing.T) {
+			toolResult, _ := c.callTool("workload_logs", map[string]interface{}{"labelSelector": "app=replicated", "container": "sidecar"})
+			if toolResult.Content[0].(mcp.TextContent).Text != "[replica-1/sidecar] third from sidecar\n" {
+				t.Errorf("unexpected logs, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("workload_logs with no matching pods returns message", func(t *testing.T) {
+			toolResult, _ := c.callTool("workload_logs", map[string]interface{}{"labelSelector": "app=nothing"})
+			if toolResult.Content[0].(mcp.TextContent).Text != "No pods found matching label selector app=nothing in namespace default" {
+				t.Errorf("unexpected message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
+
+func rolloutDeployment(image string, updatedReplicas int32) *appsv1.Deployment {
+	replicas := int32(3)
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web", Generation: 2, ResourceVersion: "10"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: image}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: updatedReplicas, AvailableReplicas: updatedReplicas},
+	}
+}
+
+func rolloutReplicaSet(revision, image string) appsv1.ReplicaSet {
+	return appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web-" + revision, Namespace: "default", UID: types.UID("uid-web-" + revision),
+			Labels:          map[string]string{"app": "web"},
+			Annotations:     map[string]string{"deployment.kubernetes.io/revision": revision, "kubernetes.io/change-cause": "deploy " + image},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "uid-web", Controller: ptr.To(true)}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: ptr.To(int32(0)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", "pod-template-hash": revision}},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: image}}},
+			},
+		},
+	}
+}
+
+func TestRollout(t *testing.T) {
+	testCase(t, func(c *mcpContext) {
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		c.withKubeConfig(mockServer.Config())
+		var patches []string
+		mockServer.Handle(test.NewDiscoveryClientHandler(
+			&metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: []string{"get", "list"}},
+			}},
+			&metav1.APIResourceList{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+				{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list", "patch", "watch"}},
+				{Name: "deployments/scale", Namespaced: true, Group: "autoscaling", Version: "v1", Kind: "Scale", Verbs: []string{"get", "patch"}},
+				{Name: "daemonsets", Namespaced: true, Kind: "DaemonSet", Verbs: []string{"get", "list", "patch", "watch"}},
+				{Name: "replicasets", Namespaced: true, Kind: "ReplicaSet", Verbs: []string{"get", "list"}},
+			}},
+		))
+		mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case req.Method == http.MethodPatch:
+				body, _ := io.ReadAll(req.Body)
+				patches = append(patches, req.URL.Path+" "+string(body))
+				if strings.HasSuffix(req.URL.Path, "/scale") {
+					test.WriteObject(w, &autoscalingv1.Scale{
+						TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v1", Kind: "Scale"},
+						ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+						Spec:       autoscalingv1.ScaleSpec{Replicas: 5},
+					})
+					return
+				}
+				test.WriteObject(w, rolloutDeployment("app:2.0", 3))
+			case req.URL.Path == "/apis/apps/v1/namespaces/default/deployments/web":
+				test.WriteObject(w, rolloutDeployment("app:2.0", 3))
+			case req.URL.Path == "/apis/apps/v1/namespaces/default/deployments/progressing":
+				deployment := rolloutDeployment("app:2.0", 1)
+				deployment.Name = "progressing"
+				test.WriteObject(w, deployment)
+			case req.URL.Path == "/apis/apps/v1/namespaces/default/deployments" && req.URL.Query().Get("watch") == "true":
+				deployment := rolloutDeployment("app:2.0", 3)
+				deployment.Name = "progressing"
+				_ = json.NewEncoder(w).Encode(&metav1.WatchEvent{Type: "MODIFIED", Object: runtime.RawExtension{Object: deployment}})
+			case req.URL.Path == "/apis/apps/v1/namespaces/default/replicasets":
+				test.WriteObject(w, &appsv1.ReplicaSetList{
+					TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSetList"},
+					Items:    []appsv1.ReplicaSet{rolloutReplicaSet("1", "app:1.0"), rolloutReplicaSet("2", "app:2.0")},
+				})
+			}
+		}))
+		t.Run("workload_scale with missing replicas returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("workload_scale", map[string]interface{}{"kind": "Deployment", "name": "web"})
+			if !toolResult.IsError || toolResult.Content[0].(mcp.TextContent).Text != "failed to scale workload, missing argument replicas" {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("workload_scale with DaemonSet returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("workload_scale", map[string]interface{}{"kind": "DaemonSet", "name": "agent", "replicas": 2})
+			if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "DaemonSet agent can't be scaled") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("workload_scale patches the scale subresource", func(t *testing.T) {
+			patches = nil
+			toolResult, err := c.callTool("workload_scale", map[string]interface{}{"kind": "Deployment", "name": "web", "replicas": 5})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if len(patches) != 1 || patches[0] != `/apis/apps/v1/namespaces/default/deployments/web/scale {"spec":{"replicas":5}}` {
+				t.Errorf("unexpected patches %v", patches)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "replicas: 5") {
+				t.Errorf("expected scale, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_restart updates the restartedAt annotation", func(t *testing.T) {
+			patches = nil
+			toolResult, err := c.callTool("rollout_restart", map[string]interface{}{"kind": "deployment", "name": "web"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if len(patches) != 1 || !strings.HasPrefix(patches[0], `/apis/apps/v1/namespaces/default/deployments/web {"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":`) {
+				t.Errorf("unexpected patches %v", patches)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Deployment default/web restarted, use rollout_status to follow the progress of the rollout" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_restart with unsupported kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_restart", map[string]interface{}{"kind": "Job", "name": "batch"})
+			if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "unsupported workload kind Job") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_status returns completed rollout", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_status", map[string]interface{}{"kind": "Deployment", "name": "web"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "deployment \"web\" successfully rolled out\n" {
+				t.Errorf("unexpected status, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_status without timeout returns current status", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_status", map[string]interface{}{"kind": "Deployment", "name": "progressing"})
+			if !strings.HasPrefix(toolResult.Content[0].(mcp.TextContent).Text, "Waiting for deployment \"progressing\" rollout to finish: 1 out of 3 new replicas have been updated") {
+				t.Errorf("unexpected status, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_status with timeout waits for the rollout to complete", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_status", map[string]interface{}{"kind": "Deployment", "name": "progressing", "timeout": 5})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "deployment \"progressing\" successfully rolled out\n" {
+				t.Errorf("unexpected status, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_history lists the revisions", func(t *testing.T) {
+			toolResult, err := c.callTool("rollout_history", map[string]interface{}{"kind": "Deployment", "name": "web"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			expected := "REVISION  CHANGE-CAUSE\n" +
+				"1         deploy app:1.0\n" +
+				"2         deploy app:2.0\n"
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, expected) {
+				t.Errorf("unexpected history, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_history with revision returns the pod template", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_history", map[string]interface{}{"kind": "Deployment", "name": "web", "revision": 1})
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "Image:\tapp:1.0") {
+				t.Errorf("unexpected revision, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+		t.Run("rollout_undo with dryRun returns the target pod template", func(t *testing.T) {
+			patches = nil
+			toolResult, err := c.callTool("rollout_undo", map[string]interface{}{"kind": "Deployment", "name": "web", "dryRun": true})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "app:1.0") || len(patches) != 0 {
+				t.Errorf("unexpected dry run, got %v %v", toolResult.Content[0].(mcp.TextContent).Text, patches)
+			}
+		})
+		t.Run("rollout_undo rolls back to the previous revision", func(t *testing.T) {
+			patches = nil
+			toolResult, err := c.callTool("rollout_undo", map[string]interface{}{"kind": "Deployment", "name": "web"})
+			if err != nil || toolResult.IsError {
+				t.Fatalf("call tool failed %v %v", err, toolResult.Content)
+			}
+			if toolResult.Content[0].(mcp.TextContent).Text != "Deployment web rolled back" {
+				t.Errorf("unexpected result, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+			if len(patches) != 1 || !strings.Contains(patches[0], `"image":"app:1.0"`) {
+				t.Errorf("unexpected patches %v", patches)
+			}
+		})
+	})
+}
+
+func TestRolloutDenied(t *testing.T) {
+	deniedResourcesServer := &config.StaticConfig{DeniedResources: []config.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "ReplicaSet"}}}
+	testCaseWithContext(t, &mcpContext{staticConfig: deniedResourcesServer}, func(c *mcpContext) {
+		t.Run("rollout_history with denied revision kind returns error", func(t *testing.T) {
+			toolResult, _ := c.callTool("rollout_history", map[string]interface{}{"kind": "Deployment", "name": "web"})
+			if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(mcp.TextContent).Text, "resource not allowed: apps/v1, Kind=ReplicaSet") {
+				t.Fatalf("invalid error message, got %v", toolResult.Content[0].(mcp.TextContent).Text)
+			}
+		})
+	})
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// relaxedJsonPath matches the field paths that can be provided without the enclosing braces and the leading dot
+// (same as kubectl custom-columns, e.g. 'metadata.name', '.metadata.name', '{metadata.name}' or '{.metadata.name}')
+var relaxedJsonPath = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
+
+// PrintJsonPath prints the result of evaluating the JSONPath template against the object (kubectl -o jsonpath).
+// Plain field paths such as '.spec.replicas' are accepted without the enclosing braces.
+func PrintJsonPath(obj runtime.Unstructured, template string) (string, error) {
+	if !strings.Contains(template, "{") {
+		relaxed, err := relaxJsonPath(template)
+		if err != nil {
+			return "", err
+		}
+		template = relaxed
+	}
+	jp := jsonpath.New("jsonpath").AllowMissingKeys(true)
+	if err := jp.Parse(template); err != nil {
+		return "", fmt.Errorf("invalid jsonpath %s: %w", template, err)
+	}
+	buf := new(bytes.Buffer)
+	if err := jp.Execute(buf, obj.UnstructuredContent()); err != nil {
+		return "", fmt.Errorf("error executing jsonpath %s: %w", template, err)
+	}
+	return buf.String(), nil
+}
+
+// PrintCustomColumns prints the object, or each of the items of the list, as a table with the provided columns (kubectl -o custom-columns).
+// Columns are provided as comma-separated HEADER:path pairs, e.g. 'NAME:.metadata.name,IMAGES:.spec.containers[*].image'
+// (commas within braces or brackets are part of the path, e.g. unions or filters).
+func PrintCustomColumns(obj runtime.Unstructured, columns string) (string, error) {
+	var headers []string
+	var parsers []*jsonpath.JSONPath
+	for _, column := range splitCustomColumns(columns) {
+		header, path, found := strings.Cut(column, ":")
+		if !found || header == "" || path == "" {
+			return "", fmt.Errorf("invalid custom column %s, expected HEADER:path", column)
+		}
+		relaxed, err := relaxJsonPath(path)
+		if err != nil {
+			return "", err
+		}
+		jp := jsonpath.New(header).AllowMissingKeys(true)
+		if err = jp.Parse(relaxed); err != nil {
+			return "", fmt.Errorf("invalid jsonpath %s for custom column %s: %w", path, header, err)
+		}
+		headers = append(headers, header)
+		parsers = append(parsers, jp)
+	}
+	var items []runtime.Object
+	if meta.IsListType(obj) {
+		var err error
+		if items, err = meta.ExtractList(obj); err != nil {
+			return "", err
+		}
+	} else {
+		items = append(items, obj)
+	}
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 10, 4, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, item := range items {
+		u, ok := item.(runtime.Unstructured)
+		if !ok {
+			return "", fmt.Errorf("unexpected item type %T", item)
+		}
+		cells := make([]string, len(parsers))
+		for i, jp := range parsers {
+			results, err := jp.FindResults(u.UnstructuredContent())
+			if err != nil {
+				return "", err
+			}
+			var values []string
+			for _, result := range results {
+				for _, value := range result {
+					values = append(values, fmt.Sprintf("%v", value.Interface()))
+				}
+			}
+			cells[i] = strings.Join(values, ",")
+			if cells[i] == "" {
+				cells[i] = "<none>"
+			}
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// splitCustomColumns splits the comma-separated columns, ignoring the commas within braces or brackets
+func splitCustomColumns(columns string) []string {
+	var ret []string
+	depth, start := 0, 0
+	for i, c := range columns {
+		switch c {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				ret = append(ret, columns[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(ret, columns[start:])
+}
+
+func relaxJsonPath(path string) (string, error) {
+	submatches := relaxedJsonPath.FindStringSubmatch(path)
+	if submatches == nil {
+		return "", fmt.Errorf("unexpected path %s, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'", path)
+	}
+	fieldSpec := submatches[1]
+	if fieldSpec == "" {
+		fieldSpec = submatches[2]
+	}
+	return fmt.Sprintf("{.%s}", fieldSpec), nil
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPrintJsonPath(t *testing.T) {
+	var deployment unstructured.Unstructured
+	_ = json.Unmarshal([]byte(`
+			{ "apiVersion": "apps/v1", "kind": "Deployment",
+			  "metadata": { "name": "web", "namespace": "default" },
+			  "spec": { "replicas": 3, "template": { "spec": { "containers": [
+			    { "name": "app", "image": "app:1.0" }, { "name": "sidecar", "image": "sidecar:2.0" }
+			  ] } } } }`), &deployment)
+	t.Run("prints relaxed field path", func(t *testing.T) {
+		out, err := PrintJsonPath(&deployment, ".spec.template.spec.containers[*].image")
+		if err != nil {
+			t.Fatalf("Error printing jsonpath: %v", err)
+		}
+		if out != "app:1.0 sidecar:2.0" {
+			t.Errorf("Unexpected output: %s", out)
+		}
+	})
+	t.Run("prints template", func(t *testing.T) {
+		out, err := PrintJsonPath(&deployment, `{.metadata.name}{" "}{.spec.replicas}`)
+		if err != nil {
+			t.Fatalf("Error printing jsonpath: %v", err)
+		}
+		if out != "web 3" {
+			t.Errorf("Unexpected output: %s", out)
+		}
+	})
+	t.Run("prints nothing for missing fields", func(t *testing.T) {
+		out, err := PrintJsonPath(&deployment, ".status.readyReplicas")
+		if err != nil {
+			t.Fatalf("Error printing jsonpath: %v", err)
+		}
+		if out != "" {
+			t.Errorf("Unexpected output: %s", out)
+		}
+	})
+	t.Run("returns error for invalid template", func(t *testing.T) {
+		if _, err := PrintJsonPath(&deployment, "{.spec.replicas"); err == nil {
+			t.Errorf("Expected error for invalid template")
+		}
+	})
+}
+
+func TestPrintCustomColumns(t *testing.T) {
+	var podList unstructured.UnstructuredList
+	_ = json.Unmarshal([]byte(`
+			{ "apiVersion": "v1", "kind": "PodList", "items": [
+			  { "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "pod-1" },
+			    "spec": { "containers": [{ "name": "a", "image": "a:1" }, { "name": "b", "image": "b:1" }] }, "status": { "phase": "Running" } },
+			  { "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "pod-2" },
+			    "spec": { "containers": [{ "name": "a", "image": "a:2" }] } }
+			]}`), &podList)
+	t.Run("prints a row per item", func(t *testing.T) {
+		out, err := PrintCustomColumns(&podList, "NAME:.metadata.name,IMAGES:.spec.containers[*].image,PHASE:status.phase")
+		if err != nil {
+			t.Fatalf("Error printing custom columns: %v", err)
+		}
+		expected := "NAME      IMAGES    PHASE\n" +
+			"pod-1     a:1,b:1   Running\n" +
+			"pod-2     a:2       <none>\n"
+		if out != expected {
+			t.Errorf("Unexpected output:\n%s", out)
+		}
+	})
+	t.Run("prints a single object", func(t *testing.T) {
+		out, err := PrintCustomColumns(&podList.Items[0], "NAME:{.metadata.name}")
+		if err != nil {
+			t.Fatalf("Error printing custom columns: %v", err)
+		}
+		if out != "NAME\npod-1\n" {
+			t.Errorf("Unexpected output:\n%s", out)
+		}
+	})
+	t.Run("keeps commas within brackets in the path", func(t *testing.T) {
+		out, err := PrintCustomColumns(&podList, `NAME:.metadata.name,A:.spec.containers[?(@.name=="a")]['name','image'],PHASE:status.phase`)
+		if err != nil {
+			t.Fatalf("Error printing custom columns: %v", err)
+		}
+		expected := "NAME      A         PHASE\n" +
+			"pod-1     a,a:1     Running\n" +
+			"pod-2     a,a:2     <none>\n"
+		if out != expected {
+			t.Errorf("Unexpected output:\n%s", out)
+		}
+	})
+	t.Run("returns error for invalid column", func(t *testing.T) {
+		if _, err := PrintCustomColumns(&podList, "NAME"); err == nil || err.Error() != "invalid custom column NAME, expected HEADER:path" {
+			t.Errorf("Expected error for invalid column, got %v", err)
+		}
+	})
+}